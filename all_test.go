@@ -1,9 +1,27 @@
 package subtest_test
 
-import "github.com/clarify/subtest"
+import (
+	"os"
+	"testing"
+
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/internal/testmock"
+)
 
 // Set up formatting rules for package unit tests.
 func init() {
 	subtest.SetTypeFormatter(nil) // Explicitly use default formatter.
 	subtest.SetIndent("\t")       // Makes it easier to validate failure output.
 }
+
+// t is used in example tests to mimic the `t *testing.T` parameter in test
+// functions.
+var t = testmock.T{
+	Name:  "ParentTest",
+	Quiet: true,
+}
+
+// TestMain enables detection of obsolete snapshots.
+func TestMain(m *testing.M) {
+	os.Exit(subtest.SnapshotMain(m))
+}
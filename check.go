@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -59,6 +62,65 @@ func (cs AllOf) Check(vf ValueFunc) error {
 	return nil
 }
 
+// AnyOf is a Check type that fails if all of it's members fails.
+type AnyOf []Check
+
+// Check runs member checks until one passes, and returns an aggregated error
+// of all member failures if none of them pass.
+func (cs AnyOf) Check(vf ValueFunc) error {
+	var errs Errors
+
+	for _, c := range cs {
+		err := c.Check(vf)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("%s: %w", msgAnyOf, errs)
+}
+
+// OneOf is a Check type that fails unless exactly one of it's members passes.
+type OneOf []Check
+
+// Check runs all member checks and returns an error unless exactly one check
+// passes. When no checks pass, an aggregated error of all member failures is
+// returned.
+func (cs OneOf) Check(vf ValueFunc) error {
+	var errs Errors
+	var passed []string
+
+	for i, c := range cs {
+		err := c.Check(vf)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			passed = append(passed, fmt.Sprintf("#%d", i))
+		}
+	}
+	switch len(passed) {
+	case 0:
+		return fmt.Errorf("%s: %w", msgOneOfNone, errs)
+	case 1:
+		return nil
+	default:
+		return Failf("%s: %s", msgOneOfMany, strings.Join(passed, ", "))
+	}
+}
+
+// Not returns a check function that fails when c passes, or when c is nil.
+func Not(c Check) CheckFunc {
+	return func(got interface{}) error {
+		if c == nil {
+			return FailGot(msgMissingCheck, c)
+		}
+		if err := c.Check(Value(got)); err == nil {
+			return FailGot(msgNot, got)
+		}
+		return nil
+	}
+}
+
 // LessThan returns a check function that fails when the test value is not a
 // numeric value less than expect.
 func LessThan(expect float64) CheckFunc {
@@ -165,6 +227,105 @@ func NumericEqual(expect float64) CheckFunc {
 	}
 }
 
+// NumericEqualAbs returns a check function that fails when the test value is
+// not a numeric value within an absolute tolerance of expect.
+func NumericEqualAbs(expect, tolerance float64) CheckFunc {
+	return func(got interface{}) error {
+		f, ok := asFloat64(got)
+		if !ok {
+			return FailGot(msgNotFloat64, got)
+		}
+		if f == expect {
+			// Also covers infinite values, where the delta would be NaN.
+			return nil
+		}
+
+		delta := math.Abs(f - expect)
+		if !(delta <= tolerance) {
+			msg := fmt.Sprintf("%s %g (delta %g)", msgNumericEqualAbs, tolerance, delta)
+			return FailExpect(msg, got, expect)
+		}
+
+		return nil
+	}
+}
+
+// NumericEqualRel returns a check function that fails when the test value is
+// not a numeric value within a relative tolerance of expect. The tolerance is
+// relative to the largest absolute value of the test value and expect.
+func NumericEqualRel(expect, tolerance float64) CheckFunc {
+	return func(got interface{}) error {
+		f, ok := asFloat64(got)
+		if !ok {
+			return FailGot(msgNotFloat64, got)
+		}
+		if f == expect {
+			// Also covers infinite values, where the delta would be NaN.
+			return nil
+		}
+
+		delta := math.Abs(f - expect)
+		allowed := tolerance * math.Max(math.Abs(f), math.Abs(expect))
+		if !(delta <= allowed) {
+			msg := fmt.Sprintf("%s %g (delta %g, allowed %g)", msgNumericEqualRel, tolerance, delta, allowed)
+			return FailExpect(msg, got, expect)
+		}
+
+		return nil
+	}
+}
+
+// NumericEqualULP returns a check function that fails when the test value is
+// not a numeric value within ulps units in the last place of expect when
+// compared as float64 values.
+func NumericEqualULP(expect float64, ulps uint64) CheckFunc {
+	return func(got interface{}) error {
+		f, ok := asFloat64(got)
+		if !ok {
+			return FailGot(msgNotFloat64, got)
+		}
+
+		delta, ok := ulpDistance(f, expect)
+		if !ok || delta > ulps {
+			msg := fmt.Sprintf("%s %d (delta %s)", msgNumericEqualULP, ulps, formatULPs(delta, ok))
+			return FailExpect(msg, got, expect)
+		}
+
+		return nil
+	}
+}
+
+// ulpDistance returns the number of representable float64 values between a
+// and b. The second return value is false if either value is NaN.
+func ulpDistance(a, b float64) (uint64, bool) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	ia, ib := orderedFloatBits(a), orderedFloatBits(b)
+	if ia > ib {
+		return uint64(ia - ib), true
+	}
+	return uint64(ib - ia), true
+}
+
+// orderedFloatBits maps f to an integer so that the integer ordering matches
+// the float ordering, and so that +0 and -0 are equal.
+func orderedFloatBits(f float64) int64 {
+	const signBit = 1 << 63
+	bits := math.Float64bits(f)
+	if bits&signBit != 0 {
+		return -int64(bits &^ signBit)
+	}
+	return int64(bits)
+}
+
+func formatULPs(delta uint64, ok bool) string {
+	if !ok {
+		return "NaN"
+	}
+	return strconv.FormatUint(delta, 10)
+}
+
 // NotBefore returns a check function that fails when the test value is before
 // expect. Accepts type time.Time and *time.Time.
 func NotBefore(expect time.Time) CheckFunc {
@@ -251,11 +412,14 @@ func NotDeepEqual(reject interface{}) CheckFunc {
 }
 
 // DeepEqual returns a check function that fails when the test value does not
-// deep equals to expect.
+// deep equals to expect. On failure, the differences between the two values are
+// described by the package diff formatter.
 func DeepEqual(expect interface{}) CheckFunc {
 	return func(got interface{}) error {
 		if !reflect.DeepEqual(expect, got) {
-			return FailExpect(msgDeepEqual, got, expect)
+			fail := FailExpect(msgDeepEqual, got, expect)
+			fail.Diff = FormatDiff(got, expect)
+			return fail
 		}
 		return nil
 	}
@@ -402,6 +566,43 @@ func ErrorIs(target error) CheckFunc {
 	}
 }
 
+// ErrorAs returns a check function that fails if the test value is not an
+// error where errors.As finds a match for target, or not an error type. The
+// matching error is passed on to c, which may be nil. Target must be a non-nil
+// pointer to an interface type or to a type implementing error. A new value of
+// the target type is allocated for each check, so target is never modified.
+func ErrorAs(target interface{}, c Check) CheckFunc {
+	return func(got interface{}) error {
+		err, ok := got.(error)
+		if !ok && got != nil {
+			return FailGot(msgNotErrorType, got)
+		}
+
+		rt := reflect.TypeOf(target)
+		if rt == nil || rt.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+			return FailGot(msgErrorAsTarget, target)
+		}
+		if et := rt.Elem(); et.Kind() != reflect.Interface && !et.Implements(errorType) {
+			return FailGot(msgErrorAsTarget, target)
+		}
+
+		rv := reflect.New(rt.Elem())
+		if !errors.As(err, rv.Interface()) {
+			msg := fmt.Sprintf("%s %s", msgErrorAs, rt.Elem())
+			return FailGot(msg, err)
+		}
+		if c == nil {
+			return nil
+		}
+		if err := c.Check(Value(rv.Elem().Interface())); err != nil {
+			return decoderError(fmt.Sprintf("error as %s", rt.Elem()), err)
+		}
+		return nil
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
 // ContainsMatch returns a check function that fails if the test value does not
 // contain the check. Accepts input of type array and slice.
 func ContainsMatch(c Check) CheckFunc {
@@ -428,6 +629,327 @@ func ContainsMatch(c Check) CheckFunc {
 	}
 }
 
+// ElementsMatch returns a check function that fails if the elements of the
+// test value do not deep equal the elements of expect, irrespective of order.
+// Missing and unexpected elements are reported separately. Accepts input of
+// type array and slice.
+func ElementsMatch(expect interface{}) CheckFunc {
+	return func(got interface{}) error {
+		rv, ev := reflect.ValueOf(got), reflect.ValueOf(expect)
+		switch {
+		case !isListKind(rv.Kind()):
+			return FailGot(msgNotSliceArrType, got)
+		case !isListKind(ev.Kind()):
+			return FailExpect(msgNotSliceArrType, got, expect)
+		}
+
+		expectMatch, gotMatch := matchElements(rv.Len(), ev.Len(), func(i, j int) bool {
+			return reflect.DeepEqual(rv.Index(i).Interface(), ev.Index(j).Interface())
+		})
+
+		var errs Errors
+		if missing := unmatched(ev, expectMatch); missing.Len() > 0 {
+			errs = append(errs, FailGot(msgElementsMissing, missing.Interface()))
+		}
+		if unexpected := unmatched(rv, gotMatch); unexpected.Len() > 0 {
+			errs = append(errs, FailGot(msgElementsUnexpected, unexpected.Interface()))
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%s: %w", msgElementsMatch, errs)
+		}
+		return nil
+	}
+}
+
+// ElementsMatchChecks returns a check function that fails unless each element
+// of the test value can be paired with a distinct check in cs that it passes,
+// irrespective of order. Checks without a matching element and unexpected
+// elements are reported separately. Accepts input of type array and slice.
+func ElementsMatchChecks(cs ...Check) CheckFunc {
+	return func(got interface{}) error {
+		rv := reflect.ValueOf(got)
+		if !isListKind(rv.Kind()) {
+			return FailGot(msgNotSliceArrType, got)
+		}
+
+		expectMatch, gotMatch := matchElements(rv.Len(), len(cs), func(i, j int) bool {
+			return cs[j].Check(Value(rv.Index(i).Interface())) == nil
+		})
+
+		var errs Errors
+		var missing []string
+		for j, i := range expectMatch {
+			if i < 0 {
+				missing = append(missing, fmt.Sprintf("#%d", j))
+			}
+		}
+		if len(missing) > 0 {
+			errs = append(errs, Failf("%s: %s", msgChecksUnmatched, strings.Join(missing, ", ")))
+		}
+		if unexpected := unmatched(rv, gotMatch); unexpected.Len() > 0 {
+			errs = append(errs, FailGot(msgElementsUnexpected, unexpected.Interface()))
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%s: %w", msgElementsMatch, errs)
+		}
+		return nil
+	}
+}
+
+// matchElements finds a maximum bipartite matching between n got elements and
+// m expectations, where match reports if got element i can be paired with
+// expectation j. It returns the index of the paired got element for each
+// expectation and vice versa, or -1 for unpaired ones.
+func matchElements(n, m int, match func(i, j int) bool) (expectMatch, gotMatch []int) {
+	edges := make([][]int, m)
+	for j := range edges {
+		for i := 0; i < n; i++ {
+			if match(i, j) {
+				edges[j] = append(edges[j], i)
+			}
+		}
+	}
+
+	expectMatch = make([]int, m)
+	gotMatch = make([]int, n)
+	for i := range gotMatch {
+		gotMatch[i] = -1
+	}
+
+	// Find augmenting paths as described by Kuhn's algorithm.
+	var visited []bool
+	var augment func(j int) bool
+	augment = func(j int) bool {
+		for _, i := range edges[j] {
+			if visited[i] {
+				continue
+			}
+			visited[i] = true
+			if gotMatch[i] < 0 || augment(gotMatch[i]) {
+				gotMatch[i] = j
+				return true
+			}
+		}
+		return false
+	}
+	for j := range edges {
+		visited = make([]bool, n)
+		augment(j)
+	}
+
+	for j := range expectMatch {
+		expectMatch[j] = -1
+	}
+	for i, j := range gotMatch {
+		if j >= 0 {
+			expectMatch[j] = i
+		}
+	}
+	return expectMatch, gotMatch
+}
+
+// unmatched returns a slice of the elements in the list rv where the
+// corresponding match index is negative.
+func unmatched(rv reflect.Value, match []int) reflect.Value {
+	l := reflect.MakeSlice(reflect.SliceOf(rv.Type().Elem()), 0, 0)
+	for i, j := range match {
+		if j < 0 {
+			l = reflect.Append(l, rv.Index(i))
+		}
+	}
+	return l
+}
+
+func isListKind(k reflect.Kind) bool {
+	return k == reflect.Array || k == reflect.Slice
+}
+
+// Sorted returns a check function that fails if the elements of the test value
+// are not sorted in increasing order. Equal elements are allowed. Accepts input
+// of type array and slice, with elements of numeric, string or time.Time type.
+// The first offending index pair is reported.
+func Sorted() CheckFunc {
+	return orderCheck(msgSorted, func(a, b reflect.Value) (bool, bool) {
+		less, ok := lessOrdered(b, a)
+		return !less, ok
+	})
+}
+
+// SortedBy returns a check function that fails if the elements of the test
+// value are not sorted according to less. Accepts input of type array and
+// slice. The first offending index pair is reported.
+func SortedBy(less func(a, b interface{}) bool) CheckFunc {
+	return orderCheck(msgSorted, func(a, b reflect.Value) (bool, bool) {
+		return !less(b.Interface(), a.Interface()), true
+	})
+}
+
+// StrictlyIncreasing returns a check function that fails if each element of
+// the test value is not strictly greater than the previous one. Accepts the
+// same input types as Sorted. The first offending index pair is reported.
+func StrictlyIncreasing() CheckFunc {
+	return orderCheck(msgStrictlyIncreasing, lessOrdered)
+}
+
+// orderCheck returns a check function that fails if inOrder returns false for
+// any pair of consecutive elements in the test value, or if it reports the
+// elements as not comparable.
+func orderCheck(msg string, inOrder func(a, b reflect.Value) (bool, bool)) CheckFunc {
+	return func(got interface{}) error {
+		rv := reflect.ValueOf(got)
+		if !isListKind(rv.Kind()) {
+			return FailGot(msgNotSliceArrType, got)
+		}
+		for i := 1; i < rv.Len(); i++ {
+			ok, comparable := inOrder(rv.Index(i-1), rv.Index(i))
+			switch {
+			case !comparable:
+				return FailGot(msgNotOrderedType, got)
+			case !ok:
+				return FailGot(fmt.Sprintf("%s at index %d and %d", msg, i-1, i), got)
+			}
+		}
+		return nil
+	}
+}
+
+// Unique returns a check function that fails if any two elements of the test
+// value deep equal each other. Accepts input of type array and slice. The first
+// offending index pair is reported.
+func Unique() CheckFunc {
+	return UniqueBy(func(v interface{}) interface{} { return v })
+}
+
+// UniqueBy returns a check function that fails if key returns deep equal
+// values for any two elements of the test value. Accepts input of type array
+// and slice. The first offending index pair is reported.
+func UniqueBy(key func(v interface{}) interface{}) CheckFunc {
+	return func(got interface{}) error {
+		rv := reflect.ValueOf(got)
+		if !isListKind(rv.Kind()) {
+			return FailGot(msgNotSliceArrType, got)
+		}
+		keys := make([]interface{}, rv.Len())
+		for j := range keys {
+			keys[j] = key(rv.Index(j).Interface())
+			for i := 0; i < j; i++ {
+				if reflect.DeepEqual(keys[i], keys[j]) {
+					return FailGot(fmt.Sprintf("%s at index %d and %d", msgUnique, i, j), got)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// lessOrdered reports whether a is less than b for values of numeric, string
+// and time.Time types. The second return value is false when a and b are not
+// of the same ordered type.
+func lessOrdered(a, b reflect.Value) (less, ok bool) {
+	if a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return false, false
+	}
+	if at, ok := a.Interface().(time.Time); ok {
+		return at.Before(b.Interface().(time.Time)), true
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float(), true
+	case reflect.String:
+		return a.String() < b.String(), true
+	}
+	return false, false
+}
+
+// Each returns a check function that runs c on every element of the test
+// value, and returns an aggregated error with one member per failing element.
+// Accepts input of type array, slice, map and channel. For maps, c is run on
+// the values in key order. For channels, c is run on the values that can be
+// received without blocking; note that these values are consumed, and are no
+// longer available to the caller after the check. The channel is not required
+// to be closed.
+func Each(c Check) CheckFunc {
+	return func(got interface{}) error {
+		var errs Errors
+		err := eachElement(got, func(e PathElement, prefix string, v interface{}) {
+			if err := c.Check(Value(v)); err != nil {
+				errs = append(errs, PathError{Prefix: prefix, Path: Path{e}, Err: err})
+			}
+		})
+		switch {
+		case err != nil:
+			return err
+		case len(errs) > 0:
+			return errs
+		}
+		return nil
+	}
+}
+
+// None returns a check function that fails if c passes for any element of
+// the test value, with one aggregated error member per matching element.
+// Accepts the same input types as Each, and consumes channel values the same
+// way.
+func None(c Check) CheckFunc {
+	return func(got interface{}) error {
+		var errs Errors
+		err := eachElement(got, func(e PathElement, prefix string, v interface{}) {
+			if err := c.Check(Value(v)); err == nil {
+				errs = append(errs, PathError{Prefix: prefix, Path: Path{e}, Err: FailGot(msgNoneMatch, v)})
+			}
+		})
+		switch {
+		case err != nil:
+			return err
+		case len(errs) > 0:
+			return errs
+		}
+		return nil
+	}
+}
+
+// eachElement calls f for each element in v, together with a path element and
+// error prefix describing the element's location.
+func eachElement(v interface{}, f func(e PathElement, prefix string, v interface{})) error {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			f(PathElement{Kind: PathIndex, Index: i}, fmt.Sprintf("on index %d", i), rv.Index(i).Interface())
+		}
+	case reflect.Map:
+		for _, k := range sortedKeys(rv) {
+			key := k.Interface()
+			f(PathElement{Kind: PathKey, Key: key}, fmt.Sprintf("on key %#v", key), rv.MapIndex(k).Interface())
+		}
+	case reflect.Chan:
+		if rv.Type().ChanDir()&reflect.RecvDir == 0 {
+			return FailGot(msgNotElementsType, v)
+		}
+		for i := 0; ; i++ {
+			x, ok := rv.TryRecv()
+			if !ok {
+				break
+			}
+			f(PathElement{Kind: PathIndex, Index: i}, fmt.Sprintf("on index %d", i), x.Interface())
+		}
+	default:
+		return FailGot(msgNotElementsType, v)
+	}
+	return nil
+}
+
 // Contains returns a check function that fails if the test value does not
 // contain the input. Accepts input of type array and slice.
 func Contains(v interface{}) CheckFunc {
@@ -2,6 +2,8 @@ package subtest
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 // OnFloat64 returns a check function where the test value is converted to
@@ -10,7 +12,7 @@ func OnFloat64(c Check) CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Float64(got))
 		if err != nil {
-			return fmt.Errorf("on float64: %w", err)
+			return decoderError("float64", err)
 		}
 		return nil
 	}
@@ -23,7 +25,7 @@ func OnLen(c Check) CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Len(got))
 		if err != nil {
-			return fmt.Errorf("on len: %w", err)
+			return decoderError("len", err)
 		}
 		return nil
 	}
@@ -36,7 +38,7 @@ func OnCap(c Check) CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Cap(got))
 		if err != nil {
-			return fmt.Errorf("on cap: %w", err)
+			return decoderError("cap", err)
 		}
 		return nil
 	}
@@ -49,12 +51,150 @@ func OnIndex(i int, c Check) CheckFunc {
 		vf := Index(got, i)
 		err := c.Check(vf)
 		if err != nil {
-			return fmt.Errorf("on index %d: %w", i, err)
+			return PathError{
+				Prefix: fmt.Sprintf("on index %d", i),
+				Path:   Path{{Kind: PathIndex, Index: i}},
+				Err:    err,
+			}
 		}
 		return nil
 	}
 }
 
+// OnTransform returns a check function where the test value is transformed by
+// f before it's passed on to c. An error returned by f is passed on to c as a
+// ValueFunc error. Errors are prefixed by "on <name>".
+func OnTransform(name string, f func(interface{}) (interface{}, error), c Check) CheckFunc {
+	return func(got interface{}) error {
+		err := c.Check(func() (interface{}, error) {
+			return f(got)
+		})
+		if err != nil {
+			return decoderError(name, err)
+		}
+		return nil
+	}
+}
+
+// On returns a check function where the test value, which must be of type T,
+// is transformed by f before it's passed on to c. An error returned by f is
+// passed on to c as a ValueFunc error. Errors are prefixed by "on <name>".
+func On[T, U any](name string, f func(T) (U, error), c Check) CheckFunc {
+	return OnTransform(name, func(got interface{}) (interface{}, error) {
+		v, ok := got.(T)
+		if !ok {
+			return nil, FailGot("type is not "+reflect.TypeOf((*T)(nil)).Elem().String(), got)
+		}
+		return f(v)
+	}, c)
+}
+
+// OnKey returns a check function where the value stored under key in the test
+// value is passed on to c. Accepted input types are maps.
+func OnKey(key interface{}, c Check) CheckFunc {
+	return func(got interface{}) error {
+		err := c.Check(Key(got, key))
+		if err != nil {
+			return PathError{
+				Prefix: fmt.Sprintf("on key %#v", key),
+				Path:   Path{{Kind: PathKey, Key: key}},
+				Err:    err,
+			}
+		}
+		return nil
+	}
+}
+
+// OnKeys returns a check function where a sorted slice of the keys in the test
+// value is passed on to c. Accepted input types are maps.
+func OnKeys(c Check) CheckFunc {
+	return func(got interface{}) error {
+		err := c.Check(Keys(got))
+		if err != nil {
+			return decoderError("keys", err)
+		}
+		return nil
+	}
+}
+
+// OnValues returns a check function where a slice of the values in the test
+// value, sorted by key, is passed on to c. Accepted input types are maps.
+func OnValues(c Check) CheckFunc {
+	return func(got interface{}) error {
+		err := c.Check(Values(got))
+		if err != nil {
+			return decoderError("values", err)
+		}
+		return nil
+	}
+}
+
+// OnMethod returns a check function where the method with the given name is
+// called on the test value with args, and the first result is passed on to c.
+// When the method returns more than one result and the last result is of type
+// error, a non-nil error is passed on to c as a ValueFunc error.
+func OnMethod(name string, c Check, args ...interface{}) CheckFunc {
+	return func(got interface{}) error {
+		err := c.Check(Method(got, name, args...))
+		if err != nil {
+			return decoderError(name+"()", err)
+		}
+		return nil
+	}
+}
+
+// OnField returns a check function where the field with the given name in the
+// test value is passed on to c. Accepted input types are structs and pointers
+// to structs.
+func OnField(name string, c Check) CheckFunc {
+	return func(got interface{}) error {
+		err := c.Check(Field(got, name))
+		if err != nil {
+			return PathError{
+				Prefix: "on field " + name,
+				Path:   Path{{Kind: PathField, Name: name}},
+				Err:    err,
+			}
+		}
+		return nil
+	}
+}
+
+// OnFieldPath returns a check function where the field at the dot-separated
+// path of field names, e.g. "Order.Customer.ID", is passed on to c. Pointers
+// are dereferenced at each step. Failures to resolve the path are reported
+// with the path up to and including the failing field.
+func OnFieldPath(path string, c Check) CheckFunc {
+	names := strings.Split(path, ".")
+	return func(got interface{}) error {
+		var p Path
+		v := got
+		for i, name := range names {
+			p = append(p, PathElement{Kind: PathField, Name: name})
+			vf := Field(v, name)
+			if i == len(names)-1 {
+				if err := c.Check(vf); err != nil {
+					return fieldPathError(p, err)
+				}
+				break
+			}
+			var err error
+			if v, err = vf(); err != nil {
+				return fieldPathError(p, fmt.Errorf("value function: %w", err))
+			}
+		}
+		return nil
+	}
+}
+
+func fieldPathError(p Path, err error) error {
+	return PathError{
+		Prefix: "on field " + strings.TrimPrefix(p.String(), "$."),
+		Path:   p,
+		Err:    err,
+	}
+}
+
 // Iterate runs the first check on index 0, the second on index 1 etc, and
 // returns an aggregated error.
 func Iterate(cs ...Check) Check {
@@ -64,3 +204,13 @@ func Iterate(cs ...Check) Check {
 	}
 	return all
 }
+
+// decoderError returns an error prefixed by "on <name>". The name is recorded
+// as a path element of kind PathDecoder.
+func decoderError(name string, err error) error {
+	return PathError{
+		Prefix: "on " + name,
+		Path:   Path{{Kind: PathDecoder, Name: name}},
+		Err:    err,
+	}
+}
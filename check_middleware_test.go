@@ -1,7 +1,10 @@
 package subtest_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/clarify/subtest"
 )
@@ -78,3 +81,329 @@ func TestOnCap(t *testing.T) {
 		})
 	})
 }
+
+type customer struct {
+	ID   string
+	note string
+}
+
+type order struct {
+	Customer *customer
+}
+
+type invoice struct {
+	Order order
+	Total int
+}
+
+func TestOnField(t *testing.T) {
+	v := invoice{Order: order{Customer: &customer{ID: "c1", note: "vip"}}, Total: 42}
+
+	t.Run("given a check OnField(Total, DeepEqual(42))", func(t *testing.T) {
+		cf := subtest.OnField("Total", subtest.DeepEqual(42))
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when cheking against &v", func(t *testing.T) {
+			vf := subtest.Value(cf(&v))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when cheking against v with a different total", func(t *testing.T) {
+			vf := subtest.Value(cf(invoice{Total: 41}))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailExpect("not deep equal", 41, 42)))
+			t.Run("then the error should be prefixed by the field name",
+				vf.MatchPattern(`^on field Total: not deep equal`),
+			)
+		})
+		t.Run(`when cheking against string("42")`, func(t *testing.T) {
+			vf := subtest.Value(cf("42"))
+			expect := subtest.FailGot("type is not struct or pointer to struct", "42")
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+	t.Run("given a check OnField(Missing, Any())", func(t *testing.T) {
+		vf := subtest.Value(subtest.OnField("Missing", subtest.Any())(v))
+		t.Run("then it should fail", vf.ErrorIs(subtest.FailGot(`no such field "Missing"`, v)))
+	})
+	t.Run("given a check OnField(note, Any())", func(t *testing.T) {
+		c := customer{ID: "c1", note: "vip"}
+		vf := subtest.Value(subtest.OnField("note", subtest.Any())(c))
+		t.Run("then it should fail", vf.ErrorIs(subtest.FailGot(`unexported field "note"`, c)))
+	})
+}
+
+func TestOnFieldPath(t *testing.T) {
+	v := invoice{Order: order{Customer: &customer{ID: "c1"}}}
+
+	t.Run("given a check OnFieldPath(Order.Customer.ID, DeepEqual(c1))", func(t *testing.T) {
+		cf := subtest.OnFieldPath("Order.Customer.ID", subtest.DeepEqual("c1"))
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when cheking against v with a different ID", func(t *testing.T) {
+			err := cf(invoice{Order: order{Customer: &customer{ID: "c2"}}})
+			vf := subtest.Value(err)
+			t.Run("then the error should be prefixed by the field path",
+				vf.MatchPattern(`^on field Order.Customer.ID: not deep equal`),
+			)
+			t.Run("then the error should be located by the field path",
+				subtest.Value(subtest.ErrorLocations(err)[0].Path.String()).DeepEqual("$.Order.Customer.ID"),
+			)
+		})
+		t.Run("when cheking against v with a nil customer", func(t *testing.T) {
+			vf := subtest.Value(cf(invoice{}))
+			t.Run("then the error should be prefixed by the field path",
+				vf.MatchPattern(`^on field Order.Customer.ID: value function: nil pointer`),
+			)
+		})
+	})
+	t.Run("given a check OnFieldPath(Order.Missing, Any())", func(t *testing.T) {
+		vf := subtest.Value(subtest.OnFieldPath("Order.Missing", subtest.Any())(v))
+		t.Run("then the error should name the missing field",
+			vf.MatchPattern(`^on field Order.Missing: value function: no such field "Missing"`),
+		)
+	})
+}
+
+func TestOnKey(t *testing.T) {
+	v := map[string]int{"a": 1, "b": 2}
+
+	t.Run("given a check OnKey(a, DeepEqual(1))", func(t *testing.T) {
+		cf := subtest.OnKey("a", subtest.DeepEqual(1))
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when cheking against v with a different value", func(t *testing.T) {
+			vf := subtest.Value(cf(map[string]int{"a": 2}))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailExpect("not deep equal", 2, 1)))
+			t.Run("then the error should be prefixed by the key",
+				vf.MatchPattern(`^on key "a": not deep equal`),
+			)
+		})
+		t.Run("when cheking against a map without the key", func(t *testing.T) {
+			vf := subtest.Value(cf(map[string]int{"b": 1}))
+			t.Run("then it should fail with missing key",
+				vf.MatchPattern(`^on key "a": value function: missing key$`),
+			)
+		})
+		t.Run("when cheking against a map with a different key type", func(t *testing.T) {
+			vf := subtest.Value(cf(map[int]int{1: 1}))
+			expect := subtest.FailGot("key type not assignable to map key type", "a")
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+		t.Run(`when cheking against string("a")`, func(t *testing.T) {
+			vf := subtest.Value(cf("a"))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailGot("type is not map", "a")))
+		})
+	})
+	t.Run("given a check OnKey([]int{1}, DeepEqual(1))", func(t *testing.T) {
+		cf := subtest.OnKey([]int{1}, subtest.DeepEqual(1))
+		t.Run("when cheking against a map with interface{} keys", func(t *testing.T) {
+			vf := subtest.Value(cf(map[interface{}]int{"a": 1}))
+			expect := subtest.FailGot("key type not comparable", []int{1})
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+}
+
+func TestOnKeys(t *testing.T) {
+	t.Run("given a check OnKeys(DeepEqual([]string{a, b, c}))", func(t *testing.T) {
+		cf := subtest.OnKeys(subtest.DeepEqual([]string{"a", "b", "c"}))
+		t.Run("when cheking against a map with keys a, b and c", func(t *testing.T) {
+			vf := subtest.Value(cf(map[string]bool{"c": true, "a": true, "b": false}))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when cheking against a map with keys a and b", func(t *testing.T) {
+			vf := subtest.Value(cf(map[string]bool{"b": true, "a": true}))
+			t.Run("then it should fail",
+				vf.MatchPattern(`^on keys: not deep equal\n`),
+			)
+		})
+	})
+	t.Run("given a check OnKeys(DeepEqual([]int{2, 10}))", func(t *testing.T) {
+		cf := subtest.OnKeys(subtest.DeepEqual([]int{2, 10}))
+		t.Run("when cheking against a map with keys 10 and 2", func(t *testing.T) {
+			vf := subtest.Value(cf(map[int]string{10: "", 2: ""}))
+			t.Run("then keys should be sorted numerically", vf.NoError())
+		})
+	})
+}
+
+func TestOnValues(t *testing.T) {
+	t.Run("given a check OnValues(DeepEqual([]int{3, 1, 2}))", func(t *testing.T) {
+		cf := subtest.OnValues(subtest.DeepEqual([]int{3, 1, 2}))
+		t.Run("when cheking against a map with values sorted by key", func(t *testing.T) {
+			vf := subtest.Value(cf(map[string]int{"b": 1, "a": 3, "c": 2}))
+			t.Run("then it should pass", vf.NoError())
+		})
+	})
+	t.Run("given a check OnValues(ContainsMatch(DeepEqual(1)))", func(t *testing.T) {
+		cf := subtest.OnValues(subtest.ContainsMatch(subtest.DeepEqual(1)))
+		t.Run("when cheking against a map without the value", func(t *testing.T) {
+			vf := subtest.Value(cf(map[string]int{"a": 2}))
+			t.Run("then it should fail",
+				vf.MatchPattern(`^on values: does not match any elements`),
+			)
+		})
+	})
+}
+
+type server struct {
+	status int
+	err    error
+}
+
+func (s server) Status() int { return s.status }
+
+func (s server) Err() error { return s.err }
+
+func (s server) Get(key string) (string, error) {
+	if key == "" {
+		return "", s.err
+	}
+	return "value of " + key, nil
+}
+
+func (s server) Join(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
+func (s *server) Addr() string { return "localhost" }
+
+func TestOnMethod(t *testing.T) {
+	errNotFound := errors.New("not found")
+	v := server{status: 200, err: errNotFound}
+
+	t.Run("given a check OnMethod(Status, DeepEqual(200))", func(t *testing.T) {
+		cf := subtest.OnMethod("Status", subtest.DeepEqual(200))
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when cheking against a server with status 500", func(t *testing.T) {
+			vf := subtest.Value(cf(server{status: 500}))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailExpect("not deep equal", 500, 200)))
+			t.Run("then the error should be prefixed by the method name",
+				vf.MatchPattern(`^on Status\(\): not deep equal`),
+			)
+		})
+		t.Run(`when cheking against string("a")`, func(t *testing.T) {
+			vf := subtest.Value(cf("a"))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailGot(`no such method "Status"`, "a")))
+		})
+		t.Run("when cheking against nil", func(t *testing.T) {
+			vf := subtest.Value(cf(nil))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailGot(`no such method "Status"`, nil)))
+		})
+	})
+	t.Run("given a check OnMethod(Addr, DeepEqual(localhost))", func(t *testing.T) {
+		cf := subtest.OnMethod("Addr", subtest.DeepEqual("localhost"))
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then a pointer receiver method should be called on a copy", vf.NoError())
+		})
+		t.Run("when cheking against &v", func(t *testing.T) {
+			vf := subtest.Value(cf(&v))
+			t.Run("then it should pass", vf.NoError())
+		})
+	})
+	t.Run("given a check OnMethod(Err, ErrorIs(errNotFound))", func(t *testing.T) {
+		cf := subtest.OnMethod("Err", subtest.ErrorIs(errNotFound))
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then a single error result should be passed on as the value", vf.NoError())
+		})
+	})
+	t.Run("given a check OnMethod(Get, DeepEqual(value of a), a)", func(t *testing.T) {
+		cf := subtest.OnMethod("Get", subtest.DeepEqual("value of a"), "a")
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then it should pass", vf.NoError())
+		})
+	})
+	t.Run("given a check OnMethod(Get, Any(), empty string)", func(t *testing.T) {
+		cf := subtest.OnMethod("Get", subtest.Any(), "")
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then a trailing error should be a value function error",
+				vf.ErrorIs(errNotFound),
+			)
+		})
+	})
+	t.Run("given a check OnMethod(Get, Any(), 42)", func(t *testing.T) {
+		cf := subtest.OnMethod("Get", subtest.Any(), 42)
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then it should fail",
+				vf.MatchPattern(`^on Get\(\): value function: arguments not matching method signature\n`),
+			)
+		})
+	})
+	t.Run("given a check OnMethod(Join, DeepEqual(a-b), -, a, b)", func(t *testing.T) {
+		cf := subtest.OnMethod("Join", subtest.DeepEqual("a-b"), "-", "a", "b")
+		t.Run("when cheking against v", func(t *testing.T) {
+			vf := subtest.Value(cf(v))
+			t.Run("then variadic arguments should be passed on", vf.NoError())
+		})
+	})
+}
+
+func TestOnTransform(t *testing.T) {
+	t.Run("given a check OnTransform(upper, ToUpper, DeepEqual(FOO))", func(t *testing.T) {
+		upper := func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("not a string")
+			}
+			return strings.ToUpper(s), nil
+		}
+		cf := subtest.OnTransform("upper", upper, subtest.DeepEqual("FOO"))
+		t.Run(`when cheking against string("foo")`, func(t *testing.T) {
+			vf := subtest.Value(cf("foo"))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run(`when cheking against string("bar")`, func(t *testing.T) {
+			vf := subtest.Value(cf("bar"))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailExpect("not deep equal", "BAR", "FOO")))
+			t.Run("then the error should be prefixed by the name",
+				vf.MatchPattern(`^on upper: not deep equal`),
+			)
+		})
+		t.Run("when cheking against int(42)", func(t *testing.T) {
+			vf := subtest.Value(cf(42))
+			t.Run("then the transform error should be a value function error",
+				vf.MatchPattern(`^on upper: value function: not a string$`),
+			)
+		})
+	})
+}
+
+func TestOn(t *testing.T) {
+	t.Run("given a check On(duration, time.ParseDuration, DeepEqual(time.Second))", func(t *testing.T) {
+		cf := subtest.On("duration", time.ParseDuration, subtest.DeepEqual(time.Second))
+		t.Run(`when cheking against string("1s")`, func(t *testing.T) {
+			vf := subtest.Value(cf("1s"))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run(`when cheking against string("2s")`, func(t *testing.T) {
+			vf := subtest.Value(cf("2s"))
+			t.Run("then it should fail",
+				vf.ErrorIs(subtest.FailExpect("not deep equal", 2*time.Second, time.Second)),
+			)
+		})
+		t.Run("when cheking against int(1)", func(t *testing.T) {
+			vf := subtest.Value(cf(1))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailGot("type is not string", 1)))
+		})
+	})
+	t.Run("given a check On(len, func([]int), DeepEqual(2))", func(t *testing.T) {
+		cf := subtest.On("len", func(v []int) (int, error) { return len(v), nil }, subtest.DeepEqual(2))
+		t.Run("when cheking against []int{1, 2}", func(t *testing.T) {
+			vf := subtest.Value(cf([]int{1, 2}))
+			t.Run("then it should pass", vf.NoError())
+		})
+	})
+}
@@ -2,6 +2,9 @@ package subtest_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"regexp"
 	"testing"
 	"time"
@@ -52,6 +55,27 @@ func TestDeepEqual(t *testing.T) {
 			)
 
 		})
+		t.Run("when checking a *T value with a different nested value", func(t *testing.T) {
+			v := &T{A: "a", B: map[string]string{"C": "D"}}
+			expect := &T{A: "a", B: map[string]string{"C": "E"}}
+			vf := subtest.Value(subtest.DeepEqual(expect)(v))
+
+			t.Run("then the failure should hold a path-annotated diff", vf.ErrorIs(subtest.Failure{
+				Prefix: "not deep equal",
+				Got:    "*subtest_test.T\n\t{A:a B:map[C:D]}",
+				Expect: "*subtest_test.T\n\t{A:a B:map[C:E]}",
+				Diff:   `$.B.C: got "D", want "E"`,
+			}))
+			t.Run("then the failure should match a target without a diff",
+				vf.ErrorIs(subtest.FailExpect("not deep equal", v, expect)),
+			)
+			t.Run("then the failure should not match a target with a different diff", vf.ErrorIsNot(subtest.Failure{
+				Prefix: "not deep equal",
+				Got:    "*subtest_test.T\n\t{A:a B:map[C:D]}",
+				Expect: "*subtest_test.T\n\t{A:a B:map[C:E]}",
+				Diff:   `$.B.C: got "D", want "F"`,
+			}))
+		})
 	})
 
 }
@@ -343,6 +367,113 @@ func TestNumericEqual(t *testing.T) {
 	})
 }
 
+func TestNumericEqualAbs(t *testing.T) {
+	t.Run("given a computed value 0.1+0.2", func(t *testing.T) {
+		a, b := 0.1, 0.2
+		v := a + b // not a constant expression.
+		t.Run("when cheking against 0.3 with tolerance 1e-9", func(t *testing.T) {
+			cf := subtest.NumericEqualAbs(0.3, 1e-9)
+			vf := subtest.Value(cf(v))
+			t.Run("then it should not fail", vf.NoError())
+		})
+		t.Run("when cheking against 0.3 with tolerance 0", func(t *testing.T) {
+			cf := subtest.NumericEqualAbs(0.3, 0)
+			vf := subtest.Value(cf(v))
+			expect := subtest.Failure{
+				Prefix: "not numeric equal within absolute tolerance 0 (delta 5.551115123125783e-17)",
+				Got:    "float64\n\t0.30000000000000004",
+				Expect: "float64\n\t0.3",
+			}
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+	t.Run("given a value int16(42)", func(t *testing.T) {
+		v := int16(42)
+		t.Run("when cheking against 43 with tolerance 1", func(t *testing.T) {
+			t.Run("then it should not fail", subtest.Value(v).NumericEqualAbs(43, 1))
+		})
+		t.Run("when cheking against 44 with tolerance 1", func(t *testing.T) {
+			cf := subtest.NumericEqualAbs(44, 1)
+			vf := subtest.Value(cf(v))
+			expect := subtest.Failure{
+				Prefix: "not numeric equal within absolute tolerance 1 (delta 2)",
+				Got:    "int16\n\t42",
+				Expect: "float64\n\t44",
+			}
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+}
+
+func TestNumericEqualInf(t *testing.T) {
+	t.Run("given a value +Inf", func(t *testing.T) {
+		v := math.Inf(1)
+		t.Run("when cheking against +Inf with absolute tolerance 0", func(t *testing.T) {
+			t.Run("then it should not fail", subtest.Value(v).NumericEqualAbs(math.Inf(1), 0))
+		})
+		t.Run("when cheking against +Inf with relative tolerance 0.01", func(t *testing.T) {
+			t.Run("then it should not fail", subtest.Value(v).NumericEqualRel(math.Inf(1), 0.01))
+		})
+		t.Run("when cheking against -Inf with absolute tolerance 1", func(t *testing.T) {
+			vf := subtest.Value(subtest.NumericEqualAbs(math.Inf(-1), 1)(v))
+			t.Run("then it should fail", vf.MatchPattern(`^not numeric equal within absolute tolerance 1 \(delta \+Inf\)`))
+		})
+	})
+}
+
+func TestNumericEqualRel(t *testing.T) {
+	t.Run("given a value float64(1000)", func(t *testing.T) {
+		v := float64(1000)
+		t.Run("when cheking against 1001 with tolerance 0.01", func(t *testing.T) {
+			t.Run("then it should not fail", subtest.Value(v).NumericEqualRel(1001, 0.01))
+		})
+		t.Run("when cheking against 1100 with tolerance 0.01", func(t *testing.T) {
+			cf := subtest.NumericEqualRel(1100, 0.01)
+			vf := subtest.Value(cf(v))
+			expect := subtest.Failure{
+				Prefix: "not numeric equal within relative tolerance 0.01 (delta 100, allowed 11)",
+				Got:    "float64\n\t1000",
+				Expect: "float64\n\t1100",
+			}
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+}
+
+func TestNumericEqualULP(t *testing.T) {
+	t.Run("given a computed value 0.1+0.2", func(t *testing.T) {
+		a, b := 0.1, 0.2
+		v := a + b // not a constant expression.
+		t.Run("when cheking against 0.3 with 1 ULP", func(t *testing.T) {
+			t.Run("then it should not fail", subtest.Value(v).NumericEqualULP(0.3, 1))
+		})
+		t.Run("when cheking against 0.3 with 0 ULPs", func(t *testing.T) {
+			cf := subtest.NumericEqualULP(0.3, 0)
+			vf := subtest.Value(cf(v))
+			expect := subtest.Failure{
+				Prefix: "not numeric equal within ULPs 0 (delta 1)",
+				Got:    "float64\n\t0.30000000000000004",
+				Expect: "float64\n\t0.3",
+			}
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+	t.Run("given a value of negative zero", func(t *testing.T) {
+		v := math.Copysign(0, -1)
+		t.Run("when cheking against positive zero with 0 ULPs", func(t *testing.T) {
+			t.Run("then it should not fail", subtest.Value(v).NumericEqualULP(0, 0))
+		})
+	})
+	t.Run("given a NaN value", func(t *testing.T) {
+		v := math.NaN()
+		t.Run("when cheking against NaN", func(t *testing.T) {
+			cf := subtest.NumericEqualULP(math.NaN(), 10)
+			vf := subtest.Value(cf(v))
+			t.Run("then it should fail", vf.MatchPattern(`^not numeric equal within ULPs 10 \(delta NaN\)`))
+		})
+	})
+}
+
 func TestNotNumericEqual(t *testing.T) {
 	t.Run("given a value float64(42)", func(t *testing.T) {
 		v := float64(42)
@@ -620,6 +751,63 @@ func TestRegexp(t *testing.T) {
 	})
 }
 
+type validationError struct {
+	Field  string
+	Reason string
+}
+
+func (err *validationError) Error() string {
+	return err.Field + ": " + err.Reason
+}
+
+func TestErrorAs(t *testing.T) {
+	t.Run("given an error wrapping a *validationError", func(t *testing.T) {
+		err := fmt.Errorf("request failed: %w", &validationError{Field: "name", Reason: "too long"})
+		vf := subtest.Value(err)
+
+		t.Run("when checking against ErrorAs(*validationError) without a check", func(t *testing.T) {
+			var target *validationError
+			t.Run("then it should pass", vf.ErrorAs(&target, nil))
+			t.Run("then target should not be modified", subtest.Value(target).ReflectNil())
+		})
+		t.Run("when checking against ErrorAs(*validationError) with a matching schema", func(t *testing.T) {
+			c := subtest.Fields{
+				"Field":  subtest.DeepEqual("name"),
+				"Reason": subtest.Any(),
+			}
+			t.Run("then it should pass", vf.ErrorAs(new(*validationError), c))
+		})
+		t.Run("when checking against ErrorAs(*validationError) with a non-matching schema", func(t *testing.T) {
+			c := subtest.Fields{
+				"Field":  subtest.DeepEqual("email"),
+				"Reason": subtest.Any(),
+			}
+			cf := subtest.ErrorAs(new(*validationError), c)
+			vf := subtest.Value(cf(err))
+			t.Run("then it should fail", vf.MatchPattern(
+				`^on error as \*subtest_test.validationError: not matching schema: 1 issue\(s\)\n`,
+			))
+			t.Run("then it should wrap the field failure",
+				vf.ErrorIs(subtest.FailExpect("not deep equal", "name", "email")),
+			)
+		})
+		t.Run("when checking against ErrorAs(*json.SyntaxError)", func(t *testing.T) {
+			cf := subtest.ErrorAs(new(*json.SyntaxError), nil)
+			vf := subtest.Value(cf(err))
+			t.Run("then it should fail", vf.ErrorIs(
+				subtest.FailGot("error is not matching target type *json.SyntaxError", err),
+			))
+		})
+	})
+	t.Run("given an invalid target", func(t *testing.T) {
+		cf := subtest.ErrorAs(validationError{}, nil)
+		vf := subtest.Value(cf(errors.New("foo")))
+		t.Run("then it should fail",
+			vf.MatchPattern("^target must be a non-nil pointer to an interface or error type"),
+		)
+	})
+}
+
 func TestContainsMatch(t *testing.T) {
 	t.Run("given a slice []string{a, b}", func(t *testing.T) {
 		v := []string{"a", "b"}
@@ -769,3 +957,324 @@ func TestContains(t *testing.T) {
 		})
 	})
 }
+
+func TestEach(t *testing.T) {
+	t.Run("given a check Each(LessThan(3))", func(t *testing.T) {
+		cf := subtest.Each(subtest.LessThan(3))
+		t.Run("when checking against []int{1, 2}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]int{1, 2}).Test(cf))
+		})
+		t.Run("when checking against [3]int{1, 3, 4}", func(t *testing.T) {
+			err := cf([3]int{1, 3, 4})
+			expect := subtest.Errors{
+				subtest.PathError{
+					Prefix: "on index 1",
+					Path:   subtest.Path{{Kind: subtest.PathIndex, Index: 1}},
+					Err:    subtest.FailGot("not less than 3.000000", 3),
+				},
+				subtest.PathError{
+					Prefix: "on index 2",
+					Path:   subtest.Path{{Kind: subtest.PathIndex, Index: 2}},
+					Err:    subtest.FailGot("not less than 3.000000", 4),
+				},
+			}
+			t.Run("then it should fail for each failing index", subtest.Value(err).ErrorIs(expect))
+		})
+		t.Run("when checking against map[string]int{a: 1, b: 5}", func(t *testing.T) {
+			err := cf(map[string]int{"a": 1, "b": 5})
+			t.Run("then it should fail for the failing key",
+				subtest.Value(err).MatchPattern(`^1 issue\(s\)\nissue #0:\n\ton key "b": not less than`),
+			)
+		})
+		t.Run("when checking against a channel holding 1 and 4", func(t *testing.T) {
+			ch := make(chan int, 2)
+			ch <- 1
+			ch <- 4
+			close(ch)
+			err := cf(ch)
+			t.Run("then it should fail for the failing index",
+				subtest.Value(err).MatchPattern(`^1 issue\(s\)\nissue #0:\n\ton index 1: not less than`),
+			)
+		})
+		t.Run("when checking against an open channel holding 1 and 2", func(t *testing.T) {
+			ch := make(chan int, 2)
+			ch <- 1
+			ch <- 2
+			err := cf(ch)
+			t.Run("then it should pass without blocking", subtest.Value(err).NoError())
+			t.Run("then the values should be consumed", subtest.Len(ch).DeepEqual(0))
+		})
+		t.Run("when checking against int(1)", func(t *testing.T) {
+			t.Run("then it should fail", subtest.Value(cf(1)).ErrorIs(
+				subtest.FailGot("type is not slice, array, map or channel", 1),
+			))
+		})
+	})
+}
+
+func TestNone(t *testing.T) {
+	t.Run("given a check None(DeepEqual(a))", func(t *testing.T) {
+		cf := subtest.None(subtest.DeepEqual("a"))
+		t.Run("when checking against []string{b, c}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]string{"b", "c"}).None(subtest.DeepEqual("a")))
+		})
+		t.Run("when checking against []string{a, b, a}", func(t *testing.T) {
+			err := cf([]string{"a", "b", "a"})
+			expect := subtest.Errors{
+				subtest.PathError{
+					Prefix: "on index 0",
+					Path:   subtest.Path{{Kind: subtest.PathIndex, Index: 0}},
+					Err:    subtest.FailGot("element is matching check", "a"),
+				},
+				subtest.PathError{
+					Prefix: "on index 2",
+					Path:   subtest.Path{{Kind: subtest.PathIndex, Index: 2}},
+					Err:    subtest.FailGot("element is matching check", "a"),
+				},
+			}
+			t.Run("then it should fail for each matching index", subtest.Value(err).ErrorIs(expect))
+		})
+	})
+}
+
+func TestAnyOf(t *testing.T) {
+	t.Run("given a check AnyOf{DeepEqual(a), DeepEqual(b)}", func(t *testing.T) {
+		c := subtest.AnyOf{subtest.DeepEqual("a"), subtest.DeepEqual("b")}
+		t.Run("when checking against a", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value("a").Test(c))
+		})
+		t.Run("when checking against b", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value("b").Test(c))
+		})
+		t.Run("when checking against c", func(t *testing.T) {
+			vf := subtest.Value(c.Check(subtest.Value("c")))
+			expect := subtest.Errors{
+				subtest.FailExpect("not deep equal", "c", "a"),
+				subtest.FailExpect("not deep equal", "c", "b"),
+			}
+			t.Run("then it should fail with all member failures", vf.ErrorIs(expect))
+			t.Run("then the failure should be prefixed", vf.MatchPattern("^no checks passed: 2 issue"))
+		})
+	})
+	t.Run("given a schema with a field check AnyOf{DeepEqual(a), ReflectNil()}", func(t *testing.T) {
+		c := subtest.Fields{
+			"foo": subtest.AnyOf{subtest.DeepEqual("a"), subtest.ReflectNil()},
+		}
+		t.Run("when checking against a map with a nil value", func(t *testing.T) {
+			v := map[string]interface{}{"foo": nil}
+			t.Run("then it should pass", subtest.Value(v).Test(c))
+		})
+		t.Run("when checking against a map with a non-matching value", func(t *testing.T) {
+			v := map[string]interface{}{"foo": "b"}
+			vf := subtest.Value(c.Check(subtest.Value(v)))
+			t.Run("then it should fail with the member failures nested",
+				vf.ErrorIs(subtest.FailGot("neither typed nor untyped nil", "b")),
+			)
+		})
+	})
+}
+
+func TestOneOf(t *testing.T) {
+	t.Run("given a check OneOf{LessThan(10), GreaterThan(5)}", func(t *testing.T) {
+		c := subtest.OneOf{subtest.LessThan(10), subtest.GreaterThan(5)}
+		t.Run("when checking against 3", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value(3).Test(c))
+		})
+		t.Run("when checking against 12", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value(12).Test(c))
+		})
+		t.Run("when checking against 7", func(t *testing.T) {
+			vf := subtest.Value(c.Check(subtest.Value(7)))
+			expect := subtest.Failf("multiple checks passed, expected exactly one: #0, #1")
+			t.Run("then it should fail", vf.ErrorIs(expect))
+		})
+	})
+	t.Run("given a check OneOf{DeepEqual(a), DeepEqual(b)}", func(t *testing.T) {
+		c := subtest.OneOf{subtest.DeepEqual("a"), subtest.DeepEqual("b")}
+		t.Run("when checking against c", func(t *testing.T) {
+			vf := subtest.Value(c.Check(subtest.Value("c")))
+			expect := subtest.Errors{
+				subtest.FailExpect("not deep equal", "c", "a"),
+				subtest.FailExpect("not deep equal", "c", "b"),
+			}
+			t.Run("then it should fail with all member failures", vf.ErrorIs(expect))
+		})
+	})
+}
+
+func TestNot(t *testing.T) {
+	t.Run("given a check Not(DeepEqual(a))", func(t *testing.T) {
+		cf := subtest.Not(subtest.DeepEqual("a"))
+		t.Run("when checking against b", func(t *testing.T) {
+			vf := subtest.Value(cf("b"))
+			t.Run("then it should pass", vf.NoError())
+		})
+		t.Run("when checking against a", func(t *testing.T) {
+			vf := subtest.Value(cf("a"))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailGot("negated check passed", "a")))
+		})
+	})
+	t.Run("given a value function that returns an error", func(t *testing.T) {
+		vf := subtest.ValueFunc(func() (interface{}, error) {
+			return nil, errors.New("oops")
+		})
+		t.Run("when checking against Not(Any())", func(t *testing.T) {
+			err := subtest.Not(subtest.Any()).Check(vf)
+			t.Run("then the error should not be inverted", subtest.Value(err).MatchPattern("^value function: oops$"))
+		})
+	})
+	t.Run("given a check Not(nil)", func(t *testing.T) {
+		cf := subtest.Not(nil)
+		t.Run("when checking against a", func(t *testing.T) {
+			vf := subtest.Value(cf("a"))
+			t.Run("then it should fail", vf.ErrorIs(subtest.FailGot("missing check", nil)))
+		})
+	})
+}
+
+func TestElementsMatch(t *testing.T) {
+	t.Run("given a check ElementsMatch([]int{1, 2, 2, 3})", func(t *testing.T) {
+		cf := subtest.ElementsMatch([]int{1, 2, 2, 3})
+		t.Run("when checking against []int{2, 3, 1, 2}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]int{2, 3, 1, 2}).Test(cf))
+		})
+		t.Run("when checking against [4]int{3, 2, 1, 2}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([4]int{3, 2, 1, 2}).Test(cf))
+		})
+		t.Run("when checking against []int{4, 2, 1, 5}", func(t *testing.T) {
+			vf := subtest.Value(cf([]int{4, 2, 1, 5}))
+			t.Run("then missing elements should be reported",
+				vf.ErrorIs(subtest.FailGot("missing elements", []int{2, 3})),
+			)
+			t.Run("then unexpected elements should be reported",
+				vf.ErrorIs(subtest.FailGot("unexpected elements", []int{4, 5})),
+			)
+			t.Run("then the error should be prefixed",
+				vf.MatchPattern(`^elements not matching: 2 issue\(s\)`),
+			)
+		})
+		t.Run("when checking against int(1)", func(t *testing.T) {
+			t.Run("then it should fail", subtest.Value(cf(1)).ErrorIs(
+				subtest.FailGot("type is not slice or array", 1),
+			))
+		})
+	})
+}
+
+func TestElementsMatchChecks(t *testing.T) {
+	t.Run("given a check ElementsMatchChecks(LessThan(3), LessThan(2))", func(t *testing.T) {
+		cf := subtest.ElementsMatchChecks(subtest.LessThan(3), subtest.LessThan(2))
+		t.Run("when checking against []int{1, 2}", func(t *testing.T) {
+			t.Run("then it should pass by pairing elements with distinct checks",
+				subtest.Value([]int{1, 2}).ElementsMatchChecks(subtest.LessThan(3), subtest.LessThan(2)),
+			)
+		})
+		t.Run("when checking against []int{1, 5}", func(t *testing.T) {
+			vf := subtest.Value(cf([]int{1, 5}))
+			t.Run("then checks without a match should be reported",
+				vf.ErrorIs(subtest.Failf("no elements matching checks: #1")),
+			)
+			t.Run("then unexpected elements should be reported",
+				vf.ErrorIs(subtest.FailGot("unexpected elements", []int{5})),
+			)
+		})
+		t.Run("when checking against []int{1}", func(t *testing.T) {
+			vf := subtest.Value(cf([]int{1}))
+			t.Run("then it should fail",
+				vf.ErrorIs(subtest.Failf("no elements matching checks: #1")),
+			)
+		})
+	})
+}
+
+func TestSorted(t *testing.T) {
+	t.Run("given a check Sorted()", func(t *testing.T) {
+		cf := subtest.Sorted()
+		t.Run("when checking against []int{1, 2, 2, 3}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]int{1, 2, 2, 3}).Sorted())
+		})
+		t.Run("when checking against []string{a, c, b}", func(t *testing.T) {
+			v := []string{"a", "c", "b"}
+			t.Run("then the first offending index pair should be reported",
+				subtest.Value(cf(v)).ErrorIs(subtest.FailGot("not sorted at index 1 and 2", v)),
+			)
+		})
+		t.Run("when checking against sorted []time.Time", func(t *testing.T) {
+			t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+			v := []time.Time{t0, t0.Add(time.Hour)}
+			t.Run("then it should pass", subtest.Value(v).Test(cf))
+		})
+		t.Run("when checking against []interface{}{1, a}", func(t *testing.T) {
+			v := []interface{}{1, "a"}
+			t.Run("then it should fail",
+				subtest.Value(cf(v)).ErrorIs(subtest.FailGot("type of elements not ordered", v)),
+			)
+		})
+		t.Run("when checking against int(1)", func(t *testing.T) {
+			t.Run("then it should fail",
+				subtest.Value(cf(1)).ErrorIs(subtest.FailGot("type is not slice or array", 1)),
+			)
+		})
+	})
+	t.Run("given a check SortedBy(length)", func(t *testing.T) {
+		byLen := func(a, b interface{}) bool { return len(a.(string)) < len(b.(string)) }
+		cf := subtest.SortedBy(byLen)
+		t.Run("when checking against []string{b, aa, ccc}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]string{"b", "aa", "ccc"}).SortedBy(byLen))
+		})
+		t.Run("when checking against []string{aa, b}", func(t *testing.T) {
+			v := []string{"aa", "b"}
+			t.Run("then it should fail",
+				subtest.Value(cf(v)).ErrorIs(subtest.FailGot("not sorted at index 0 and 1", v)),
+			)
+		})
+	})
+}
+
+func TestStrictlyIncreasing(t *testing.T) {
+	t.Run("given a check StrictlyIncreasing()", func(t *testing.T) {
+		cf := subtest.StrictlyIncreasing()
+		t.Run("when checking against []float64{1, 1.5, 2}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]float64{1, 1.5, 2}).StrictlyIncreasing())
+		})
+		t.Run("when checking against []int{1, 2, 2}", func(t *testing.T) {
+			v := []int{1, 2, 2}
+			t.Run("then it should fail",
+				subtest.Value(cf(v)).ErrorIs(subtest.FailGot("not strictly increasing at index 1 and 2", v)),
+			)
+		})
+	})
+}
+
+func TestUnique(t *testing.T) {
+	t.Run("given a check Unique()", func(t *testing.T) {
+		cf := subtest.Unique()
+		t.Run("when checking against []int{1, 2, 3}", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]int{1, 2, 3}).Unique())
+		})
+		t.Run("when checking against []int{1, 2, 3, 2, 1}", func(t *testing.T) {
+			v := []int{1, 2, 3, 2, 1}
+			t.Run("then the first offending index pair should be reported",
+				subtest.Value(cf(v)).ErrorIs(subtest.FailGot("not unique at index 1 and 3", v)),
+			)
+		})
+	})
+	t.Run("given a check UniqueBy(ID)", func(t *testing.T) {
+		type item struct {
+			ID   int
+			Name string
+		}
+		byID := func(v interface{}) interface{} { return v.(item).ID }
+		cf := subtest.UniqueBy(byID)
+		t.Run("when checking against items with distinct IDs", func(t *testing.T) {
+			v := []item{{ID: 1, Name: "a"}, {ID: 2, Name: "a"}}
+			t.Run("then it should pass", subtest.Value(v).UniqueBy(byID))
+		})
+		t.Run("when checking against items with a repeated ID", func(t *testing.T) {
+			v := []item{{ID: 1, Name: "a"}, {ID: 1, Name: "b"}}
+			t.Run("then it should fail",
+				subtest.Value(cf(v)).ErrorIs(subtest.FailGot("not unique at index 0 and 1", v)),
+			)
+		})
+	})
+}
@@ -0,0 +1,246 @@
+package subtest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxDiffLines limits the number of differences reported by the default
+	// diff formatter.
+	maxDiffLines = 32
+	// diffNone is used in place of a value for missing elements and keys.
+	diffNone = "<none>"
+)
+
+// NoDiff is a diff formatter that never reports any differences. Pass it to
+// SetDiffFormatter to disable diff output in failures.
+func NoDiff(got, expect interface{}) string {
+	return ""
+}
+
+// FormatDiff formats the differences between got and expect using the
+// configured diff formatter for the package. An empty string is returned if
+// there are no differences to report.
+func FormatDiff(got, expect interface{}) string {
+	f := fmtCfg.diff
+	if f == nil {
+		return defaultDiffFormatter(got, expect)
+	}
+	return f(got, expect)
+}
+
+// defaultDiffFormatter walks got and expect via reflection, and returns one
+// line per difference, annotated by the path of the difference as formatted by
+// Path.String. Differences at the root of the value are not reported, as they
+// are already fully described by the got and want output of a failure.
+func defaultDiffFormatter(got, expect interface{}) string {
+	d := differ{visited: make(map[visit]bool)}
+	d.diff(nil, reflect.ValueOf(got), reflect.ValueOf(expect))
+
+	if len(d.lines) == 0 {
+		return ""
+	}
+	if d.skipped > 0 {
+		d.lines = append(d.lines, fmt.Sprintf("... and %d more difference(s)", d.skipped))
+	}
+	return strings.Join(d.lines, "\n")
+}
+
+type visit struct {
+	got, expect uintptr
+	typ         reflect.Type
+}
+
+type differ struct {
+	lines   []string
+	skipped int
+	visited map[visit]bool
+}
+
+func (d *differ) report(path Path, got, expect string) {
+	if len(path) == 0 {
+		return
+	}
+	if len(d.lines) >= maxDiffLines {
+		d.skipped++
+		return
+	}
+	d.lines = append(d.lines, fmt.Sprintf("%s: got %s, want %s", path, got, expect))
+}
+
+func (d *differ) reportValues(path Path, got, expect reflect.Value) {
+	if got.IsValid() && expect.IsValid() && got.Type() != expect.Type() {
+		d.report(path, formatDiffTypedValue(got), formatDiffTypedValue(expect))
+		return
+	}
+	d.report(path, formatDiffValue(got), formatDiffValue(expect))
+}
+
+func (d *differ) diff(path Path, got, expect reflect.Value) {
+	switch {
+	case !got.IsValid() && !expect.IsValid():
+		return
+	case !got.IsValid() || !expect.IsValid(), got.Type() != expect.Type():
+		d.reportValues(path, got, expect)
+		return
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if got.IsNil() != expect.IsNil() {
+			d.reportValues(path, got, expect)
+			return
+		}
+		if got.IsNil() || got.Pointer() == expect.Pointer() {
+			return
+		}
+		v := visit{got: got.Pointer(), expect: expect.Pointer(), typ: got.Type()}
+		if d.visited[v] {
+			return
+		}
+		d.visited[v] = true
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr:
+		d.diff(path, got.Elem(), expect.Elem())
+	case reflect.Interface:
+		if got.IsNil() != expect.IsNil() {
+			d.reportValues(path, got, expect)
+			return
+		}
+		d.diff(path, got.Elem(), expect.Elem())
+	case reflect.Struct:
+		t := got.Type()
+		for i := 0; i < got.NumField(); i++ {
+			d.diff(path.append(PathElement{Kind: PathField, Name: t.Field(i).Name}), got.Field(i), expect.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if got.Type().Elem().Kind() == reflect.Uint8 && got.Kind() == reflect.Slice {
+			if !bytes.Equal(got.Bytes(), expect.Bytes()) {
+				d.reportValues(path, got, expect)
+			}
+			return
+		}
+		d.diffList(path, got, expect)
+	case reflect.Map:
+		d.diffMap(path, got, expect)
+	case reflect.Func:
+		// Mirror reflect.DeepEqual; functions are only equal when both are nil.
+		if !got.IsNil() || !expect.IsNil() {
+			d.reportValues(path, got, expect)
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if got.Pointer() != expect.Pointer() {
+			d.reportValues(path, got, expect)
+		}
+	default:
+		if !equalScalar(got, expect) {
+			d.reportValues(path, got, expect)
+		}
+	}
+}
+
+func (d *differ) diffList(path Path, got, expect reflect.Value) {
+	n := got.Len()
+	if expect.Len() > n {
+		n = expect.Len()
+	}
+	for i := 0; i < n; i++ {
+		iPath := path.append(PathElement{Kind: PathIndex, Index: i})
+		switch {
+		case i >= got.Len():
+			d.report(iPath, diffNone, formatDiffValue(expect.Index(i)))
+		case i >= expect.Len():
+			d.report(iPath, formatDiffValue(got.Index(i)), diffNone)
+		default:
+			d.diff(iPath, got.Index(i), expect.Index(i))
+		}
+	}
+}
+
+func (d *differ) diffMap(path Path, got, expect reflect.Value) {
+	keys := got.MapKeys()
+	for _, k := range expect.MapKeys() {
+		if !got.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	for _, k := range keys {
+		kPath := path.append(PathElement{Kind: PathKey, Key: diffKey(k)})
+		gv, ev := got.MapIndex(k), expect.MapIndex(k)
+		switch {
+		case !gv.IsValid():
+			d.report(kPath, diffNone, formatDiffValue(ev))
+		case !ev.IsValid():
+			d.report(kPath, formatDiffValue(gv), diffNone)
+		default:
+			d.diff(kPath, gv, ev)
+		}
+	}
+}
+
+// diffKey returns the map key k as an interface value. Keys that can't be
+// interfaced, e.g. from unexported struct fields, are returned as a string.
+func diffKey(k reflect.Value) interface{} {
+	if k.CanInterface() {
+		return k.Interface()
+	}
+	return fmt.Sprint(k)
+}
+
+func equalScalar(got, expect reflect.Value) bool {
+	switch got.Kind() {
+	case reflect.Bool:
+		return got.Bool() == expect.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return got.Int() == expect.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return got.Uint() == expect.Uint()
+	case reflect.Float32, reflect.Float64:
+		return got.Float() == expect.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return got.Complex() == expect.Complex()
+	case reflect.String:
+		return got.String() == expect.String()
+	}
+	return true
+}
+
+func formatDiffValue(rv reflect.Value) string {
+	switch {
+	case !rv.IsValid():
+		return "untyped nil"
+	case isNilKind(rv.Kind()) && rv.IsNil():
+		return "nil"
+	case rv.CanInterface():
+		return strings.ReplaceAll(FormatType(rv.Interface()), "\n", " ")
+	case rv.Kind() == reflect.String:
+		return quoteString(rv.String())
+	default:
+		return fmt.Sprintf("%+v", rv)
+	}
+}
+
+func isNilKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+func formatDiffTypedValue(rv reflect.Value) string {
+	if !rv.IsValid() {
+		return formatDiffValue(rv)
+	}
+	return fmt.Sprintf("%s(%s)", rv.Type(), formatDiffValue(rv))
+}
@@ -0,0 +1,67 @@
+package subtest_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func TestFormatDiff(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int
+	}
+	type Order struct {
+		Items []Item
+		Meta  map[string]interface{}
+		Note  *string
+	}
+
+	t.Run("given two scalar values", func(t *testing.T) {
+		t.Run("then no diff should be reported",
+			subtest.Value(subtest.FormatDiff(10, 12)).DeepEqual(""),
+		)
+	})
+	t.Run("given two orders with different items", func(t *testing.T) {
+		got := Order{Items: []Item{{"a", 10}, {"b", 10}}}
+		expect := Order{Items: []Item{{"a", 10}, {"b", 12}, {"c", 1}}}
+		t.Run("then each difference should be reported with a path",
+			subtest.Value(subtest.FormatDiff(got, expect)).DeepEqual(
+				"$.Items[1].Price: got 10, want 12\n"+
+					"$.Items[2]: got <none>, want {Name:c Price:1}",
+			),
+		)
+	})
+	t.Run("given two orders with different meta data", func(t *testing.T) {
+		got := Order{Meta: map[string]interface{}{"a": 1, "b": true}}
+		expect := Order{Meta: map[string]interface{}{"a": 1.0, "c": "x"}}
+		t.Run("then missing keys, extra keys and type differences should be reported",
+			subtest.Value(subtest.FormatDiff(got, expect)).DeepEqual(
+				`$.Meta.a: got int(1), want float64(1)`+"\n"+
+					`$.Meta.b: got true, want <none>`+"\n"+
+					`$.Meta.c: got <none>, want "x"`,
+			),
+		)
+	})
+	t.Run("given two orders where only one has a nil note", func(t *testing.T) {
+		note := "note"
+		got := Order{}
+		expect := Order{Note: &note}
+		t.Run("then the nil value should be reported",
+			subtest.Value(subtest.FormatDiff(got, expect)).DeepEqual(
+				`$.Note: got nil, want note`,
+			),
+		)
+	})
+	t.Run("given the NoDiff diff formatter is set", func(t *testing.T) {
+		subtest.SetDiffFormatter(subtest.NoDiff)
+		defer subtest.SetDiffFormatter(nil)
+
+		got := Order{Items: []Item{{"a", 10}}}
+		expect := Order{Items: []Item{{"a", 12}}}
+		vf := subtest.Value(subtest.DeepEqual(expect)(got))
+		t.Run("then DeepEqual failures should not include a diff", vf.ErrorAs(
+			&subtest.Failure{}, subtest.OnField("Diff", subtest.DeepEqual("")),
+		))
+	})
+}
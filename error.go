@@ -8,15 +8,36 @@ import (
 )
 
 const (
-	msgNotLenType      = "type does not support len"
-	msgNotCapType      = "type does not support cap"
-	msgNotIndexType    = "type does not support index operation"
-	msgNotErrorType    = "type is not error"
-	msgNotSliceArrType = "type is not slice or array"
-	msgNotTimeType     = "type is not time.Time or *time.Time"
-	msgNotFloat64      = "not convertable to float64"
+	msgNotLenType       = "type does not support len"
+	msgNotCapType       = "type does not support cap"
+	msgNotIndexType     = "type does not support index operation"
+	msgNotErrorType     = "type is not error"
+	msgNotSliceArrType  = "type is not slice or array"
+	msgNotMapStructType = "type is not map or struct"
+	msgNotStructType    = "type is not struct or pointer to struct"
+	msgNotMapType       = "type is not map"
+	msgNotElementsType  = "type is not slice, array, map or channel"
+	msgNotTimeType      = "type is not time.Time or *time.Time"
+	msgNotFloat64       = "not convertable to float64"
+	msgNotFuncType      = "type is not a non-nil function"
+	msgNotRecoveredType = "type is not subtest.Recovered"
 
 	msgIndexOutOfRange = "index out of range"
+	msgNoSuchField     = "no such field"
+	msgUnexportedField = "unexported field"
+	msgNilPointer      = "nil pointer"
+	msgMissingKey      = "missing key"
+	msgKeyType         = "key type not assignable to map key type"
+	msgKeyComparable   = "key type not comparable"
+	msgNoSuchMethod    = "no such method"
+	msgMethodArgs      = "arguments not matching method signature"
+	msgMethodNoResult  = "method has no results"
+
+	msgAnyOf        = "no checks passed"
+	msgOneOfNone    = "no checks passed, expected exactly one"
+	msgOneOfMany    = "multiple checks passed, expected exactly one"
+	msgNot          = "negated check passed"
+	msgMissingCheck = "missing check"
 
 	msgLessThan           = "not less than"
 	msgLessThanOrEqual    = "not less than or equal to"
@@ -24,6 +45,9 @@ const (
 	msgGreaterThanOrEqual = "not greater than or equal to"
 	msgNotNumericEqual    = "numeric equal"
 	msgNumericEqual       = "not numeric equal"
+	msgNumericEqualAbs    = "not numeric equal within absolute tolerance"
+	msgNumericEqualRel    = "not numeric equal within relative tolerance"
+	msgNumericEqualULP    = "not numeric equal within ULPs"
 
 	msgBefore       = "time not before"
 	msgNotBefore    = "time before"
@@ -43,13 +67,35 @@ const (
 	msgMatchRegexp   = "regular expression not matching "
 
 	msgContainsMatch = "does not match any elements"
+	msgNoneMatch     = "element is matching check"
+
+	msgElementsMatch      = "elements not matching"
+	msgElementsMissing    = "missing elements"
+	msgElementsUnexpected = "unexpected elements"
+	msgChecksUnmatched    = "no elements matching checks"
+
+	msgNotOrderedType     = "type of elements not ordered"
+	msgSorted             = "not sorted"
+	msgStrictlyIncreasing = "not strictly increasing"
+	msgUnique             = "not unique"
 
 	msgNoError    = "error is not nil"
 	msgError      = "error is nil"
 	msgErrorIsNot = "error is matching target error"
 	msgErrorIs    = "error is not matching target error"
+	msgErrorAs    = "error is not matching target type"
+
+	msgErrorAsTarget = "target must be a non-nil pointer to an interface or error type"
+
+	msgSchemaMatch     = "not matching schema"
+	msgListSchemaMatch = "not matching list schema"
+	msgDuplicateItem   = "duplicate of item at index"
+
+	msgPanic   = "function did not panic"
+	msgNoPanic = "function panicked"
 
-	msgSchemaMatch = "not matching schema"
+	msgEventually   = "not passing within"
+	msgConsistently = "not passing for"
 )
 
 // Failure is an error type that aid with consistent formatting of test
@@ -60,6 +106,7 @@ type Failure struct {
 	Got    string
 	Expect string
 	Reject string
+	Diff   string
 
 	next error
 }
@@ -116,6 +163,9 @@ func (f Failure) Error() string {
 	if f.Reject != "" {
 		s += fmt.Sprintf(fmtS, "don't want", f.Reject)
 	}
+	if f.Diff != "" {
+		s += "\ndiff:\n" + indentString(f.Diff)
+	}
 	return s
 }
 
@@ -124,19 +174,36 @@ func (f Failure) Unwrap() error {
 	return f.next
 }
 
-// Is returns true if f matches target.
+// Is returns true if f matches target. The diff is only compared when set in
+// target.
 func (f Failure) Is(target error) bool {
 	f2, match := target.(Failure)
 	match = match && f.Prefix == f2.Prefix
 	match = match && f.Got == f2.Got
 	match = match && f.Expect == f2.Expect
 	match = match && f.Reject == f2.Reject
+	match = match && (f2.Diff == "" || f.Diff == f2.Diff)
 	return match
 }
 
-// KeyError returns an error prefixed by a key.
+// KeyError returns an error prefixed by a key. The key is recorded as a path
+// element of kind PathKey.
 func KeyError(key interface{}, err error) error {
-	return fmt.Errorf("key %#v: %w", key, err)
+	return PathError{
+		Prefix: fmt.Sprintf("key %#v", key),
+		Path:   Path{{Kind: PathKey, Key: key}},
+		Err:    err,
+	}
+}
+
+// fieldError returns an error prefixed by a struct field name, formatted like
+// KeyError. The name is recorded as a path element of kind PathField.
+func fieldError(name string, err error) error {
+	return PathError{
+		Prefix: fmt.Sprintf("key %#v", name),
+		Path:   Path{{Kind: PathField, Name: name}},
+		Err:    err,
+	}
 }
 
 // Errors combine the output of multiple errors on separate lines.
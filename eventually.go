@@ -0,0 +1,109 @@
+package subtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock describes the time functions used by checks that re-evaluate a value
+// function over time.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func clockOrDefault(c Clock) Clock {
+	if c == nil {
+		return systemClock{}
+	}
+	return c
+}
+
+// EventuallyCheck is a Check type that re-evaluates the value function until
+// the Member check passes, or until the timeout is reached.
+type EventuallyCheck struct {
+	Member   Check
+	Timeout  time.Duration
+	Interval time.Duration
+	// Clock, if set, replaces the system clock. This allows testing without
+	// sleeping.
+	Clock Clock
+}
+
+// Eventually returns a check that re-evaluates vf every interval until c passes
+// or timeout is reached.
+func Eventually(c Check, timeout, interval time.Duration) EventuallyCheck {
+	return EventuallyCheck{
+		Member:   c,
+		Timeout:  timeout,
+		Interval: interval,
+	}
+}
+
+// Check runs c.Member against vf until it passes. When the timeout is reached,
+// the last failure is returned together with the number of attempts.
+func (c EventuallyCheck) Check(vf ValueFunc) error {
+	if vf == nil {
+		return FailGot("missing value function", vf)
+	}
+	clock := clockOrDefault(c.Clock)
+	deadline := clock.Now().Add(c.Timeout)
+
+	for attempt := 1; ; attempt++ {
+		err := c.Member.Check(vf)
+		if err == nil {
+			return nil
+		}
+		if !clock.Now().Before(deadline) {
+			return fmt.Errorf("%s %v after %d attempt(s): %w", msgEventually, c.Timeout, attempt, err)
+		}
+		clock.Sleep(c.Interval)
+	}
+}
+
+// ConsistentlyCheck is a Check type that re-evaluates the value function until
+// the duration is reached, and fails if the Member check fails at any point.
+type ConsistentlyCheck struct {
+	Member   Check
+	Duration time.Duration
+	Interval time.Duration
+	// Clock, if set, replaces the system clock. This allows testing without
+	// sleeping.
+	Clock Clock
+}
+
+// Consistently returns a check that re-evaluates vf every interval for the
+// given duration, and fails if c fails at any attempt.
+func Consistently(c Check, duration, interval time.Duration) ConsistentlyCheck {
+	return ConsistentlyCheck{
+		Member:   c,
+		Duration: duration,
+		Interval: interval,
+	}
+}
+
+// Check runs c.Member against vf until the duration is reached. The first
+// failure is returned together with the attempt number.
+func (c ConsistentlyCheck) Check(vf ValueFunc) error {
+	if vf == nil {
+		return FailGot("missing value function", vf)
+	}
+	clock := clockOrDefault(c.Clock)
+	deadline := clock.Now().Add(c.Duration)
+
+	for attempt := 1; ; attempt++ {
+		err := c.Member.Check(vf)
+		if err != nil {
+			return fmt.Errorf("%s %v on attempt %d: %w", msgConsistently, c.Duration, attempt, err)
+		}
+		if !clock.Now().Before(deadline) {
+			return nil
+		}
+		clock.Sleep(c.Interval)
+	}
+}
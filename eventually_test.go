@@ -0,0 +1,66 @@
+package subtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clarify/subtest"
+)
+
+// fakeClock is a subtest.Clock where Sleep advances the time instantly.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time        { return c.now }
+func (c *fakeClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+// counter returns a value function that returns 1, 2, 3, etc. on subsequent
+// calls.
+func counter() subtest.ValueFunc {
+	var i int
+	return func() (interface{}, error) {
+		i++
+		return i, nil
+	}
+}
+
+func TestEventually(t *testing.T) {
+	t.Run("given a check Eventually(GreaterThan(3), 1s, 100ms) with a fake clock", func(t *testing.T) {
+		c := subtest.Eventually(subtest.GreaterThan(3), time.Second, 100*time.Millisecond)
+		c.Clock = &fakeClock{}
+
+		t.Run("when checking against a counter", func(t *testing.T) {
+			t.Run("then it should pass", counter().Test(c))
+		})
+		t.Run("when checking against a static value", func(t *testing.T) {
+			err := c.Check(subtest.Value(2))
+			t.Run("then it should fail with the attempt count",
+				subtest.Value(err).MatchPattern(`^not passing within 1s after 11 attempt\(s\): not greater than`),
+			)
+			t.Run("then it should wrap the last failure",
+				subtest.Value(err).ErrorIs(subtest.FailGot("not greater than 3.000000", 2)),
+			)
+		})
+	})
+}
+
+func TestConsistently(t *testing.T) {
+	t.Run("given a check Consistently(LessThan(5), 1s, 100ms) with a fake clock", func(t *testing.T) {
+		c := subtest.Consistently(subtest.LessThan(5), time.Second, 100*time.Millisecond)
+		c.Clock = &fakeClock{}
+
+		t.Run("when checking against a static value", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value(2).Test(c))
+		})
+		t.Run("when checking against a counter", func(t *testing.T) {
+			err := c.Check(counter())
+			t.Run("then it should fail with the attempt number",
+				subtest.Value(err).MatchPattern(`^not passing for 1s on attempt 5: not less than`),
+			)
+			t.Run("then it should wrap the failure",
+				subtest.Value(err).ErrorIs(subtest.FailGot("not less than 5.000000", 5)),
+			)
+		})
+	})
+}
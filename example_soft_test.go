@@ -0,0 +1,52 @@
+package subtest_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func ExampleValueFunc_TestSoft() {
+	t.Run("soft", func(t *testing.T) {
+		subtest.Value(2).TestSoft(subtest.DeepEqual(1))(t)
+		subtest.Value(3).TestSoft(subtest.DeepEqual(1))(t)
+	})
+
+	// Output:
+	// --- FAIL: ParentTest/soft (0.00s)
+	//     example_soft_test.go:11: not deep equal
+	//         got: int
+	//         	2
+	//         want: int
+	//         	1
+	//     example_soft_test.go:12: not deep equal
+	//         got: int
+	//         	3
+	//         want: int
+	//         	1
+}
+
+func ExampleSoft() {
+	t.Run("soft", func(t *testing.T) {
+		s := subtest.Soft(t)
+		s.Test("a", subtest.Value(1), subtest.DeepEqual(1))
+		s.Test("b", subtest.Value(2), subtest.DeepEqual(1))
+		s.Test("c", subtest.Value(3), subtest.DeepEqual(1))
+	})
+
+	// Output:
+	// --- FAIL: ParentTest/soft (0.00s)
+	//     example_soft_test.go:33: b: not deep equal
+	//         got: int
+	//         	2
+	//         want: int
+	//         	1
+	//     example_soft_test.go:34: c: not deep equal
+	//         got: int
+	//         	3
+	//         want: int
+	//         	1
+	//     example_soft_test.go:31: 2 of 3 check(s) failed:
+	//         b
+	//         c
+}
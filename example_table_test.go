@@ -0,0 +1,35 @@
+package subtest_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func ExampleTable_failingRows() {
+	t.Run("table", func(t *testing.T) {
+		subtest.Table{
+			{Name: "a", Value: subtest.Value(1), Check: subtest.DeepEqual(1)},
+			{Name: "b", Value: subtest.Value(2), Check: subtest.DeepEqual(1)},
+			{Name: "c", Value: subtest.Value(3), Check: subtest.DeepEqual(1)},
+		}.Run(t)
+	})
+
+	// Output:
+	// --- FAIL: ParentTest/table (0.00s)
+	//     --- FAIL: ParentTest/table/b (0.00s)
+	//         example_table_test.go:15: not deep equal
+	//             got: int
+	//             	2
+	//             want: int
+	//             	1
+	//     --- FAIL: ParentTest/table/c (0.00s)
+	//         example_table_test.go:15: not deep equal
+	//             got: int
+	//             	3
+	//             want: int
+	//             	1
+	//     example_table_test.go:15: 2 of 3 table row(s) failed:
+	//         ParentTest/table/b
+	//         ParentTest/table/c
+}
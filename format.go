@@ -9,6 +9,7 @@ import (
 
 var fmtCfg = struct {
 	f      func(v ...interface{}) string
+	diff   func(got, expect interface{}) string
 	indent string
 }{indent: "    "}
 
@@ -19,6 +20,15 @@ func SetTypeFormatter(f func(...interface{}) string) {
 	fmtCfg.f = f
 }
 
+// SetDiffFormatter replaces the diff formatter used by the package to describe
+// differences in failures from DeepEqual. When f is nil, the default diff
+// formatter is used. To keep failures free of diff output, pass NoDiff. This
+// function is not thread-safe, and should be called as part of initialization
+// only. E.g. in a test package init function.
+func SetDiffFormatter(f func(got, expect interface{}) string) {
+	fmtCfg.diff = f
+}
+
 // SetIndent sets a string to use in package error and type formatting. The
 // default is four spaces, as that's what's used by the Go test-runner.  This
 // function is not thread-safe, and should be called as part of initialization
@@ -0,0 +1,32 @@
+package gwt_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/gwt"
+	"github.com/clarify/subtest/internal/testmock"
+)
+
+// t is used in example tests to mimic the `t *testing.T` parameter in test
+// functions.
+var t = testmock.T{
+	Name:  "ParentTest",
+	Quiet: true,
+}
+
+func ExampleMustThen() {
+	t.Run("Given a failing precondition", func(t *testing.T) {
+		gwt.MustThen(t, "it should be ok", subtest.Value(false).DeepEqual(true))
+		gwt.Then(t, "it should not run", subtest.Value(true).DeepEqual(true))
+	})
+
+	// Output:
+	// --- FAIL: ParentTest/Given_a_failing_precondition (0.00s)
+	//     --- FAIL: ParentTest/Given_a_failing_precondition/Then_it_should_be_ok (0.00s)
+	//         example_test.go:20: not deep equal
+	//             got: bool
+	//                 false
+	//             want: bool
+	//                 true
+}
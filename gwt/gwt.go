@@ -0,0 +1,68 @@
+// Package gwt contains helpers for structuring sub-tests after the
+// Given-When-Then (GWT) naming schema. Each helper runs a sub-test, prefixing
+// the name with the relevant keyword unless it's already present. Setup is
+// done at the start of each sub-test function, and teardown can be registered
+// per level via t.Cleanup.
+package gwt
+
+import (
+	"strings"
+	"testing"
+)
+
+// Given runs f as a sub-test of t named "Given <name>". It reports whether f
+// succeeded.
+func Given(t *testing.T, name string, f func(t *testing.T)) bool {
+	t.Helper()
+	return t.Run(prefixed("Given", name), f)
+}
+
+// When runs f as a sub-test of t named "When <name>". It reports whether f
+// succeeded.
+func When(t *testing.T, name string, f func(t *testing.T)) bool {
+	t.Helper()
+	return t.Run(prefixed("When", name), f)
+}
+
+// MustWhen runs f as a sub-test of t named "When <name>". If f fails, t is
+// stopped via t.FailNow, aborting any sibling When that has not yet run.
+func MustWhen(t *testing.T, name string, f func(t *testing.T)) {
+	t.Helper()
+	if !When(t, name, f) {
+		t.FailNow()
+	}
+}
+
+// Then runs f as a sub-test of t named "Then <name>". It reports whether f
+// succeeded.
+func Then(t *testing.T, name string, f func(t *testing.T)) bool {
+	t.Helper()
+	return t.Run(prefixed("Then", name), f)
+}
+
+// MustThen runs f as a sub-test of t named "Then <name>". If f fails, t is
+// stopped via t.FailNow, aborting any sibling Then that has not yet run.
+func MustThen(t *testing.T, name string, f func(t *testing.T)) {
+	t.Helper()
+	if !Then(t, name, f) {
+		t.FailNow()
+	}
+}
+
+// And runs f as a sub-test of t named "And <name>". It reports whether f
+// succeeded.
+func And(t *testing.T, name string, f func(t *testing.T)) bool {
+	t.Helper()
+	return t.Run(prefixed("And", name), f)
+}
+
+// prefixed returns name prefixed by keyword, unless name already start with
+// keyword in any letter case.
+func prefixed(keyword, name string) string {
+	if len(name) > len(keyword) &&
+		strings.EqualFold(name[:len(keyword)], keyword) &&
+		name[len(keyword)] == ' ' {
+		return name
+	}
+	return keyword + " " + name
+}
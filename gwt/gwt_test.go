@@ -0,0 +1,98 @@
+package gwt_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/gwt"
+)
+
+func TestNames(t *testing.T) {
+	var names []string
+	record := func(t *testing.T) { names = append(names, t.Name()) }
+
+	gwt.Given(t, "a value", func(t *testing.T) {
+		record(t)
+		gwt.And(t, "another value", record)
+		gwt.When(t, "when combining them", func(t *testing.T) {
+			record(t)
+			gwt.Then(t, "Then the result should be ok", record)
+		})
+	})
+
+	t.Run("then keywords should be added when missing", subtest.Value(names).DeepEqual([]string{
+		"TestNames/Given_a_value",
+		"TestNames/Given_a_value/And_another_value",
+		"TestNames/Given_a_value/when_combining_them",
+		"TestNames/Given_a_value/when_combining_them/Then_the_result_should_be_ok",
+	}))
+}
+
+func TestCleanup(t *testing.T) {
+	var events []string
+	gwt.Given(t, "a resource", func(t *testing.T) {
+		events = append(events, "setup given")
+		t.Cleanup(func() { events = append(events, "teardown given") })
+
+		gwt.When(t, "using it", func(t *testing.T) {
+			events = append(events, "setup when")
+			t.Cleanup(func() { events = append(events, "teardown when") })
+
+			gwt.MustThen(t, "it should be set up", func(t *testing.T) {
+				events = append(events, "then")
+			})
+		})
+	})
+
+	t.Run("then teardown should happen per level", subtest.Value(events).DeepEqual([]string{
+		"setup given",
+		"setup when",
+		"then",
+		"teardown when",
+		"teardown given",
+	}))
+}
+
+// abortEnv is set when running TestAbort in a sub-process.
+const abortEnv = "GWT_TEST_ABORT"
+
+func TestAbort(t *testing.T) {
+	if os.Getenv(abortEnv) != "" {
+		gwt.Given(t, "a failing MustWhen", func(t *testing.T) {
+			gwt.MustWhen(t, "failing", func(t *testing.T) { t.Fail() })
+			gwt.When(t, "next", func(t *testing.T) {})
+		})
+		gwt.Given(t, "a failing MustThen", func(t *testing.T) {
+			gwt.When(t, "checking", func(t *testing.T) {
+				gwt.MustThen(t, "failing", func(t *testing.T) { t.Fail() })
+				gwt.Then(t, "next", func(t *testing.T) {})
+			})
+			gwt.When(t, "checking again", func(t *testing.T) {})
+		})
+		return
+	}
+
+	// Failing steps can not be contained within a test, so they are run in a
+	// sub-process.
+	cmd := exec.Command(os.Args[0], "-test.run=^TestAbort$", "-test.v")
+	cmd.Env = append(os.Environ(), abortEnv+"=1")
+	b, err := cmd.CombinedOutput()
+	out := string(b)
+
+	t.Run("then the test process should fail", subtest.Value(err).Error())
+	t.Run("then the failing steps should run", subtest.Value(out).Test(subtest.AllOf{
+		subtest.MatchPattern(`(?m)^=== RUN   TestAbort/Given_a_failing_MustWhen/When_failing$`),
+		subtest.MatchPattern(`(?m)^=== RUN   TestAbort/Given_a_failing_MustThen/When_checking/Then_failing$`),
+	}))
+	t.Run("then sibling steps after a failing MustWhen should not run", subtest.Value(out).Test(
+		subtest.Not(subtest.MatchPattern(`(?m)^=== RUN   TestAbort/Given_a_failing_MustWhen/When_next$`)),
+	))
+	t.Run("then sibling steps after a failing MustThen should not run", subtest.Value(out).Test(
+		subtest.Not(subtest.MatchPattern(`(?m)^=== RUN   TestAbort/Given_a_failing_MustThen/When_checking/Then_next$`)),
+	))
+	t.Run("then steps outside the parent of a failing MustThen should run", subtest.Value(out).MatchPattern(
+		`(?m)^=== RUN   TestAbort/Given_a_failing_MustThen/When_checking_again$`,
+	))
+}
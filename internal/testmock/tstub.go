@@ -1,6 +1,7 @@
 package testmock
 
 import (
+	"flag"
 	"strings"
 	"testing"
 )
@@ -8,16 +9,34 @@ import (
 // T mimics the *testing.T type in example tests.
 type T struct {
 	Name string
+	// Quiet, if set, disables verbose output while running f, so that only
+	// failing tests are reported. This makes the output independent of the -v
+	// flag and of the Go version.
+	Quiet bool
 }
 
 // Run mimics the (*testing.T).Run method.
 func (t T) Run(name string, f func(t *testing.T)) {
+	if t.Quiet {
+		defer setFlag("test.v", "false")()
+	}
 	testing.RunTests(matchAll, []testing.InternalTest{{
 		F:    f,
 		Name: t.Name + "/" + rewrite(name),
 	}})
 }
 
+// setFlag sets the named flag to value, and returns a function for restoring
+// the previous value.
+func setFlag(name, value string) func() {
+	f := flag.Lookup(name)
+	prev := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		panic(err)
+	}
+	return func() { f.Value.Set(prev) }
+}
+
 func matchAll(pat, str string) (bool, error) {
 	return true, nil
 }
@@ -0,0 +1,112 @@
+package subtest
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Recovered holds the outcome of calling a function that may panic.
+type Recovered struct {
+	// Panicked is true if the function panicked.
+	Panicked bool
+	// Value holds the value passed to panic.
+	Value interface{}
+	// Stack holds the formatted stack trace of the panicking goroutine.
+	Stack string
+}
+
+// String formats r with the panic value and stack trace.
+func (r Recovered) String() string {
+	if !r.Panicked {
+		return "no panic"
+	}
+	return fmt.Sprintf("panic: %v\n\n%s", r.Value, r.Stack)
+}
+
+// Panics returns a new ValueFunc that calls f and returns a Recovered value,
+// describing if and how f panicked.
+func Panics(f func()) ValueFunc {
+	return func() (interface{}, error) {
+		if f == nil {
+			return nil, FailGot(msgNotFuncType, f)
+		}
+		return recoverPanic(f), nil
+	}
+}
+
+func recoverPanic(f func()) (r Recovered) {
+	panicked := true
+	defer func() {
+		if panicked {
+			r = Recovered{
+				Panicked: true,
+				Value:    recover(),
+				Stack:    string(debug.Stack()),
+			}
+		}
+	}()
+	f()
+	panicked = false
+	return Recovered{}
+}
+
+func asRecovered(got interface{}) (Recovered, bool) {
+	switch gt := got.(type) {
+	case Recovered:
+		return gt, true
+	case *Recovered:
+		if gt != nil {
+			return *gt, true
+		}
+	}
+	return Recovered{}, false
+}
+
+// Panic returns a check function that fails if the test value is not a
+// Recovered value from a function that panicked.
+func Panic() CheckFunc {
+	return func(got interface{}) error {
+		r, ok := asRecovered(got)
+		if !ok {
+			return FailGot(msgNotRecoveredType, got)
+		}
+		if !r.Panicked {
+			return FailGot(msgPanic, got)
+		}
+		return nil
+	}
+}
+
+// NoPanic returns a check function that fails if the test value is a Recovered
+// value from a function that panicked, or not a Recovered value.
+func NoPanic() CheckFunc {
+	return func(got interface{}) error {
+		r, ok := asRecovered(got)
+		if !ok {
+			return FailGot(msgNotRecoveredType, got)
+		}
+		if r.Panicked {
+			return FailGot(msgNoPanic, got)
+		}
+		return nil
+	}
+}
+
+// PanicMatch returns a check function that fails if the test value is not a
+// Recovered value from a function that panicked, or if the panic value does not
+// pass c.
+func PanicMatch(c Check) CheckFunc {
+	return func(got interface{}) error {
+		r, ok := asRecovered(got)
+		if !ok {
+			return FailGot(msgNotRecoveredType, got)
+		}
+		if !r.Panicked {
+			return FailGot(msgPanic, got)
+		}
+		if err := c.Check(Value(r.Value)); err != nil {
+			return decoderError("panic value", err)
+		}
+		return nil
+	}
+}
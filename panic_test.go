@@ -0,0 +1,54 @@
+package subtest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func TestPanics(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	t.Run("given a function that panics with an error", func(t *testing.T) {
+		vf := subtest.Panics(func() { panic(errFoo) })
+		t.Run("then Panic should pass", vf.Panic())
+		t.Run("then PanicMatch(ErrorIs(errFoo)) should pass", vf.PanicMatch(subtest.ErrorIs(errFoo)))
+		t.Run("then PanicMatch(MatchPattern(^foo$)) should pass", vf.PanicMatch(subtest.MatchPattern("^foo$")))
+
+		v, _ := vf()
+		t.Run("then the stack should include the panic location",
+			subtest.Value(v.(subtest.Recovered).Stack).MatchPattern(`panic_test\.go`),
+		)
+		t.Run("then NoPanic should fail",
+			subtest.Value(subtest.NoPanic()(v)).MatchPattern("^function panicked\ngot: subtest.Recovered\n\t`panic: foo\n"),
+		)
+	})
+	t.Run("given a function that does not panic", func(t *testing.T) {
+		vf := subtest.Panics(func() {})
+		t.Run("then NoPanic should pass", vf.NoPanic())
+
+		v, _ := vf()
+		t.Run("then Panic should fail",
+			subtest.Value(subtest.Panic()(v)).ErrorIs(subtest.FailGot("function did not panic", subtest.Recovered{})),
+		)
+		t.Run("then PanicMatch should fail",
+			subtest.Value(subtest.PanicMatch(subtest.Any())(v)).ErrorIs(subtest.FailGot("function did not panic", subtest.Recovered{})),
+		)
+	})
+	t.Run("given a function that panics with a string", func(t *testing.T) {
+		vf := subtest.Panics(func() { panic("oops") })
+		err := subtest.PanicMatch(subtest.DeepEqual("foo")).Check(vf)
+		t.Run("then PanicMatch(DeepEqual(foo)) should fail on the panic value",
+			subtest.Value(err).ErrorIs(subtest.FailExpect("not deep equal", "oops", "foo")),
+		)
+		t.Run("then the failure should be prefixed",
+			subtest.Value(err).MatchPattern("^on panic value: not deep equal"),
+		)
+	})
+	t.Run("given a non-Recovered value", func(t *testing.T) {
+		t.Run("then Panic should fail",
+			subtest.Value(subtest.Panic()(42)).ErrorIs(subtest.FailGot("type is not subtest.Recovered", 42)),
+		)
+	})
+}
@@ -0,0 +1,146 @@
+package subtest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PathKind describes the kind of step a PathElement represents.
+type PathKind int
+
+// Valid path kinds.
+const (
+	// PathKey is a step into a map value by key.
+	PathKey PathKind = iota
+	// PathIndex is a step into an array, slice or string value by index.
+	PathIndex
+	// PathField is a step into a struct value by field name.
+	PathField
+	// PathDecoder is a step that decodes or transforms a value without
+	// changing the location within the value.
+	PathDecoder
+)
+
+// PathElement describes a single step into a value.
+type PathElement struct {
+	Kind PathKind
+	// Key holds the map key for PathKey elements.
+	Key interface{}
+	// Index holds the index for PathIndex elements.
+	Index int
+	// Name holds the field name for PathField elements, and the decoder name
+	// for PathDecoder elements.
+	Name string
+}
+
+var rePathIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// String formats e as a JSONPath-like path component. PathDecoder elements
+// are formatted as an empty string.
+func (e PathElement) String() string {
+	switch e.Kind {
+	case PathKey:
+		if s, ok := e.Key.(string); ok {
+			if rePathIdent.MatchString(s) {
+				return "." + s
+			}
+			return "[" + strconv.Quote(s) + "]"
+		}
+		return fmt.Sprintf("[%#v]", e.Key)
+	case PathIndex:
+		return fmt.Sprintf("[%d]", e.Index)
+	case PathField:
+		return "." + e.Name
+	default:
+		return ""
+	}
+}
+
+// Path describes the location of a value within another value.
+type Path []PathElement
+
+// String formats p as a JSONPath-like location, e.g. `$.items[2].price`.
+func (p Path) String() string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, e := range p {
+		sb.WriteString(e.String())
+	}
+	return sb.String()
+}
+
+// append returns a copy of p with e appended, leaving p unchanged.
+func (p Path) append(e PathElement) Path {
+	return append(p[:len(p):len(p)], e)
+}
+
+// PathError is an error type used by middleware and schema checks to record
+// where within the test value an error occurred. Path holds the steps taken by
+// a single middleware, relative to the value it received. The formatted error
+// is the same as for fmt.Errorf("%s: %w", Prefix, Err).
+type PathError struct {
+	Prefix string
+	Path   Path
+	Err    error
+}
+
+func (e PathError) Error() string {
+	return e.Prefix + ": " + e.Err.Error()
+}
+
+// Unwrap returns the next error in the chain.
+func (e PathError) Unwrap() error {
+	return e.Err
+}
+
+// Location describes an error together with the path of the value that
+// caused it.
+type Location struct {
+	Path Path
+	Err  error
+}
+
+func (l Location) String() string {
+	return l.Path.String() + ": " + l.Err.Error()
+}
+
+// ErrorLocations returns the path and the remaining error for each failure
+// within err. Aggregated errors of type Errors result in one location per
+// member error. Within each location, Err holds the error that remains after
+// the last path element is unwrapped.
+func ErrorLocations(err error) []Location {
+	if err == nil {
+		return nil
+	}
+	var locs []Location
+	appendLocations(&locs, nil, err)
+	return locs
+}
+
+func appendLocations(locs *[]Location, path Path, err error) {
+	leaf := err
+	for err != nil {
+		switch et := err.(type) {
+		case PathError:
+			path = append(path[:len(path):len(path)], et.Path...)
+			leaf = et.Err
+		case Errors:
+			for _, sub := range et {
+				if sub != nil {
+					appendLocations(locs, path, sub)
+				}
+			}
+			return
+		case Failure:
+			// The error chain of a failure describes the test value, and
+			// not the result of any further checks.
+			err = nil
+			continue
+		}
+		err = errors.Unwrap(err)
+	}
+	*locs = append(*locs, Location{Path: path, Err: leaf})
+}
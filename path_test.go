@@ -0,0 +1,86 @@
+package subtest_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func TestPath(t *testing.T) {
+	t.Run("given a path with all kinds of elements", func(t *testing.T) {
+		p := subtest.Path{
+			{Kind: subtest.PathKey, Key: "items"},
+			{Kind: subtest.PathDecoder, Name: "float64"},
+			{Kind: subtest.PathIndex, Index: 2},
+			{Kind: subtest.PathField, Name: "Price"},
+			{Kind: subtest.PathKey, Key: "a b"},
+			{Kind: subtest.PathKey, Key: 42},
+		}
+		t.Run("then it should format as a JSONPath-like location",
+			subtest.Value(p.String()).DeepEqual(`$.items[2].Price["a b"][42]`),
+		)
+	})
+	t.Run("given an empty path", func(t *testing.T) {
+		t.Run("then it should format as the root location",
+			subtest.Value(subtest.Path{}.String()).DeepEqual("$"),
+		)
+	})
+}
+
+func TestErrorLocations(t *testing.T) {
+	type Item struct {
+		Price float64
+	}
+	v := map[string]interface{}{
+		"items": []interface{}{Item{Price: 1}, Item{Price: 2}, &Item{Price: 10}},
+		"name":  "foo",
+	}
+	c := subtest.Fields{
+		"items": subtest.AllOf{
+			subtest.OnLen(subtest.DeepEqual(3)),
+			subtest.OnIndex(2, subtest.Fields{
+				"Price": subtest.OnFloat64(subtest.LessThan(5)),
+			}),
+		},
+		"name": subtest.DeepEqual("bar"),
+	}
+
+	t.Run("given a nested check failing at two locations", func(t *testing.T) {
+		locs := subtest.ErrorLocations(c.Check(subtest.Value(v)))
+
+		t.Run("then two locations should be returned", subtest.Value(locs).Test(subtest.OnLen(subtest.DeepEqual(2))))
+		t.Run("then the first location should have the correct path",
+			subtest.Value(locs[0].Path.String()).DeepEqual("$.items[2].Price"),
+		)
+		t.Run("then the first location should have the correct path elements",
+			subtest.Value(locs[0].Path).DeepEqual(subtest.Path{
+				{Kind: subtest.PathKey, Key: "items"},
+				{Kind: subtest.PathIndex, Index: 2},
+				{Kind: subtest.PathField, Name: "Price"},
+				{Kind: subtest.PathDecoder, Name: "float64"},
+			}),
+		)
+		t.Run("then the first location should hold the remaining error",
+			subtest.Value(locs[0].Err).ErrorIs(subtest.FailGot("not less than 5.000000", float64(10))),
+		)
+		t.Run("then the second location should have the correct path",
+			subtest.Value(locs[1].Path.String()).DeepEqual("$.name"),
+		)
+		t.Run("then the second location should hold the remaining error",
+			subtest.Value(locs[1].Err).ErrorIs(subtest.FailExpect("not deep equal", "foo", "bar")),
+		)
+	})
+	t.Run("given a passing check", func(t *testing.T) {
+		locs := subtest.ErrorLocations(subtest.Any().Check(subtest.Value(v)))
+		t.Run("then no locations should be returned", subtest.Value(locs).DeepEqual([]subtest.Location(nil)))
+	})
+	t.Run("given a KeyError", func(t *testing.T) {
+		err := subtest.KeyError("foo", subtest.Failf("oops"))
+		t.Run("then the error should be formatted with a key prefix",
+			subtest.Value(err).MatchPattern(`^key "foo": oops$`),
+		)
+		t.Run("then the error should match the wrapped error",
+			subtest.Value(err).ErrorIs(subtest.Failf("oops")),
+		)
+	})
+}
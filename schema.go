@@ -7,10 +7,12 @@ import (
 	"strings"
 )
 
-// Fields allow validating each value in a map against a particular check.
+// Fields allow validating each value in a map or struct against a particular
+// check.
 type Fields map[interface{}]Check
 
-// Check validates vf against m, expecting vf to return a map.
+// Check validates vf against m, expecting vf to return a map, a struct or a
+// pointer to a struct.
 func (m Fields) Check(vf ValueFunc) error {
 	s := Schema{
 		Fields: m,
@@ -32,8 +34,8 @@ func (m Fields) OrderedKeys() []interface{} {
 	return keys
 }
 
-// Schema allow validating each value in a map against a particular check, just
-// like the Fields type, but with additional configuration options.
+// Schema allow validating each value in a map or struct against a particular
+// check, just like the Fields type, but with additional configuration options.
 type Schema struct {
 	// Fields contain a mapping of keys to checks.
 	Fields Fields
@@ -49,7 +51,8 @@ type Schema struct {
 	AdditionalFields Check
 }
 
-// Check validates vf against s, expecting vf to return a map.
+// Check validates vf against s, expecting vf to return a map, a struct or a
+// pointer to a struct. For structs, exported field names are used as keys.
 func (s Schema) Check(vf ValueFunc) error {
 	if vf == nil {
 		return FailGot("missing value function", vf)
@@ -60,12 +63,16 @@ func (s Schema) Check(vf ValueFunc) error {
 	}
 
 	rv := reflect.ValueOf(got)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+		rv = rv.Elem()
+	}
 	switch rv.Kind() {
 	case reflect.Map:
 		return s.checkMap(got)
-		// TODO: handle reflect.Struct
+	case reflect.Struct:
+		return s.checkStruct(rv)
 	default:
-		return FailGot("not a map", got)
+		return FailGot(msgNotMapStructType, got)
 	}
 }
 
@@ -76,8 +83,38 @@ func (s Schema) checkMap(got interface{}) error {
 	}
 
 	rKeys := rv.MapKeys()
-	sort.Slice(rKeys, func(i, j int) bool {
-		return fmt.Sprint(rKeys[i].Interface()) < fmt.Sprint(rKeys[j].Interface())
+	keys := make([]interface{}, 0, len(rKeys))
+	values := make(map[interface{}]interface{}, len(rKeys))
+	for _, rk := range rKeys {
+		k := rk.Interface()
+		keys = append(keys, k)
+		values[k] = rv.MapIndex(rk).Interface()
+	}
+	return s.checkKeys(keys, values, KeyError)
+}
+
+func (s Schema) checkStruct(rv reflect.Value) error {
+	rt := rv.Type()
+
+	keys := make([]interface{}, 0, rt.NumField())
+	values := make(map[interface{}]interface{}, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" {
+			// Skip unexported fields.
+			continue
+		}
+		keys = append(keys, f.Name)
+		values[f.Name] = rv.Field(i).Interface()
+	}
+	return s.checkKeys(keys, values, func(k interface{}, err error) error {
+		return fieldError(k.(string), err)
+	})
+}
+
+func (s Schema) checkKeys(keys []interface{}, values map[interface{}]interface{}, keyError func(interface{}, error) error) error {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
 	})
 
 	var errs Errors
@@ -85,12 +122,10 @@ func (s Schema) checkMap(got interface{}) error {
 
 	var check Check
 	var vf ValueFunc
-	var k interface{}
 
-	for _, rk := range rKeys {
+	for _, k := range keys {
 		check = nil
-		k = rk.Interface()
-		vf = Value(rv.MapIndex(rk).Interface())
+		vf = Value(values[k])
 
 		if s.Fields != nil {
 			check = s.Fields[k]
@@ -103,18 +138,13 @@ func (s Schema) checkMap(got interface{}) error {
 			continue
 		}
 		if err := check.Check(vf); err != nil {
-			errs = append(errs, KeyError(k, err))
+			errs = append(errs, keyError(k, err))
 		}
 	}
 	if len(extraKeys) > 0 {
 		errs = append(errs, Failf("got additional keys: %v", strings.Join(extraKeys, ", ")))
 	}
 
-	keySet := make(map[interface{}]struct{}, rv.Len())
-	for _, rk := range rKeys {
-		keySet[rk.Interface()] = struct{}{}
-	}
-
 	var required []interface{}
 	if s.Required == nil {
 		required = s.Fields.OrderedKeys()
@@ -124,7 +154,7 @@ func (s Schema) checkMap(got interface{}) error {
 
 	var missingKeys []string
 	for _, k := range required {
-		_, ok := keySet[k]
+		_, ok := values[k]
 		if !ok {
 			missingKeys = append(missingKeys, fmt.Sprintf("%#v", k))
 		}
@@ -138,3 +168,97 @@ func (s Schema) checkMap(got interface{}) error {
 	}
 	return nil
 }
+
+// ListSchema allow validating the items in an array or slice, as well as the
+// list itself.
+type ListSchema struct {
+	// Prefix, if set, contain checks for the first items in the list, by
+	// position. Items missing from the list are reported as errors.
+	Prefix []Check
+	// Items, if set, contain a check used to validate all items in the list.
+	Items Check
+	// MinItems sets the minimum number of items in the list.
+	MinItems int
+	// MaxItems, if greater than 0, sets the maximum number of items in the
+	// list.
+	MaxItems int
+	// UniqueItems, if set, requires all items in the list to be unique as
+	// determined by reflect.DeepEqual.
+	UniqueItems bool
+	// Contains, if set, contain a check that at least one item in the list must
+	// pass.
+	Contains Check
+}
+
+// Check validates vf against s, expecting vf to return an array or slice.
+func (s ListSchema) Check(vf ValueFunc) error {
+	if vf == nil {
+		return FailGot("missing value function", vf)
+	}
+	got, err := vf()
+	if err != nil {
+		return FailGot("value function returns an error", err)
+	}
+
+	rv := reflect.ValueOf(got)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return FailGot(msgNotSliceArrType, got)
+	}
+	l := rv.Len()
+
+	var errs Errors
+
+	if l < s.MinItems {
+		errs = append(errs, Failf("got %d item(s), want at least %d", l, s.MinItems))
+	}
+	if s.MaxItems > 0 && l > s.MaxItems {
+		errs = append(errs, Failf("got %d item(s), want at most %d", l, s.MaxItems))
+	}
+	for i, c := range s.Prefix {
+		if err := OnIndex(i, c)(got); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Items != nil {
+		for i := 0; i < l; i++ {
+			if err := OnIndex(i, s.Items)(got); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if s.UniqueItems {
+		errs = append(errs, duplicateItems(rv)...)
+	}
+	if s.Contains != nil {
+		if err := ContainsMatch(s.Contains)(got); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", msgListSchemaMatch, errs)
+	}
+	return nil
+}
+
+// duplicateItems returns one error for each item in rv that deep equals an
+// earlier item.
+func duplicateItems(rv reflect.Value) Errors {
+	var errs Errors
+	for j := 1; j < rv.Len(); j++ {
+		vj := rv.Index(j).Interface()
+		for i := 0; i < j; i++ {
+			if reflect.DeepEqual(rv.Index(i).Interface(), vj) {
+				errs = append(errs, PathError{
+					Prefix: fmt.Sprintf("on index %d", j),
+					Path:   Path{{Kind: PathIndex, Index: j}},
+					Err:    FailGot(fmt.Sprintf("%s %d", msgDuplicateItem, i), vj),
+				})
+				break
+			}
+		}
+	}
+	return errs
+}
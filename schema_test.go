@@ -54,6 +54,67 @@ func TestSchema_map(t *testing.T) {
 	})
 }
 
+func TestSchema_struct(t *testing.T) {
+	type T struct {
+		Name  string
+		Price int
+		note  string
+	}
+	v := T{Name: "foo", Price: 42, note: "unexported"}
+
+	t.Run("given a schema covering all exported fields", func(t *testing.T) {
+		c := subtest.Fields{
+			"Name":  subtest.DeepEqual("foo"),
+			"Price": subtest.GreaterThan(41),
+		}
+		t.Run("then it should match a T value", subtest.Value(v).Test(c))
+		t.Run("then it should match a *T value", subtest.Value(&v).Test(c))
+	})
+	t.Run("given a schema with a failing field check", func(t *testing.T) {
+		c := subtest.Fields{
+			"Name":  subtest.DeepEqual("bar"),
+			"Price": subtest.Any(),
+		}
+		vf := subtest.Value(c.Check(subtest.Value(v)))
+		t.Run("then the error should be reported by field name",
+			vf.MatchPattern(`key "Name": not deep equal`),
+		)
+	})
+	t.Run("given a schema without checks for all exported fields", func(t *testing.T) {
+		c := subtest.Schema{
+			Fields: subtest.Fields{"Name": subtest.Any()},
+		}
+		vf := subtest.Value(c.Check(subtest.Value(v)))
+		t.Run("then additional fields should result in an error",
+			vf.ErrorIs(subtest.Failf(`got additional keys: "Price"`)),
+		)
+	})
+	t.Run("given a schema with allowed additional fields", func(t *testing.T) {
+		c := subtest.Schema{
+			Fields:           subtest.Fields{"Name": subtest.Any()},
+			AdditionalFields: subtest.Any(),
+		}
+		t.Run("then it should match a T value", subtest.Value(v).Test(c))
+	})
+	t.Run("given a schema requiring unknown and unexported fields", func(t *testing.T) {
+		c := subtest.Schema{
+			Required:         []interface{}{"Name", "Missing", "note"},
+			AdditionalFields: subtest.Any(),
+		}
+		vf := subtest.Value(c.Check(subtest.Value(v)))
+		t.Run("then missing fields should result in an error",
+			vf.ErrorIs(subtest.Failf(`missing required keys: "Missing", "note"`)),
+		)
+	})
+	t.Run("given a nil *T value", func(t *testing.T) {
+		c := subtest.Schema{AdditionalFields: subtest.Any()}
+		vf := subtest.Value(c.Check(subtest.Value((*T)(nil))))
+		t.Run("then it should fail",
+			vf.ErrorIs(subtest.FailGot("type is not map or struct", (*T)(nil))),
+		)
+	})
+}
+
 func TestSchema_JSON(t *testing.T) {
 	const v = `{"foo": "bar", "bar": 42}`
 
@@ -86,3 +147,85 @@ func floatGt(compare float64) subtest.CheckFunc {
 		return nil
 	}
 }
+
+func TestListSchema(t *testing.T) {
+	t.Run("given a list schema with item and prefix checks", func(t *testing.T) {
+		c := subtest.ListSchema{
+			Prefix: []subtest.Check{subtest.DeepEqual("id")},
+			Items:  subtest.NotDeepEqual(""),
+		}
+		t.Run("then it should match a []string value",
+			subtest.Value([]string{"id", "a", "b"}).Test(c),
+		)
+		t.Run("then it should match a [2]string value",
+			subtest.Value([2]string{"id", "a"}).Test(c),
+		)
+
+		vf := subtest.Value(c.Check(subtest.Value([]string{"key", "", "b", ""})))
+		t.Run("then each offending index should be reported",
+			vf.MatchPattern(`(?s)^not matching list schema: 3 issue\(s\)\n`+
+				`.*on index 0: not deep equal`+
+				`.*on index 1: deep equal`+
+				`.*on index 3: deep equal`),
+		)
+		t.Run("then the error should be located by index",
+			subtest.Value(fmt.Sprint(subtest.ErrorLocations(c.Check(subtest.Value([]string{"id", ""})))[0].Path)).
+				DeepEqual("$[1]"),
+		)
+		t.Run("then missing prefix items should result in an error",
+			subtest.Value(c.Check(subtest.Value([]string{}))).MatchPattern(
+				`(?s)^not matching list schema: .*on index 0: value function: index out of range`,
+			),
+		)
+	})
+	t.Run("given a list schema with length constraints", func(t *testing.T) {
+		c := subtest.ListSchema{MinItems: 1, MaxItems: 2}
+		t.Run("then it should match a list within range",
+			subtest.Value([]int{1, 2}).Test(c),
+		)
+		t.Run("then too few items should result in an error",
+			subtest.Value(c.Check(subtest.Value([]int{}))).ErrorIs(
+				subtest.Failf("got 0 item(s), want at least 1"),
+			),
+		)
+		t.Run("then too many items should result in an error",
+			subtest.Value(c.Check(subtest.Value([]int{1, 2, 3}))).ErrorIs(
+				subtest.Failf("got 3 item(s), want at most 2"),
+			),
+		)
+	})
+	t.Run("given a list schema requiring unique items", func(t *testing.T) {
+		c := subtest.ListSchema{UniqueItems: true}
+		t.Run("then it should match a list of unique items",
+			subtest.Value([]int{1, 2, 3}).Test(c),
+		)
+		vf := subtest.Value(c.Check(subtest.Value([]int{1, 2, 1, 1})))
+		t.Run("then each duplicate should be reported",
+			vf.MatchPattern(`(?s)^not matching list schema: 2 issue\(s\)\n`+
+				`.*on index 2: duplicate of item at index 0`+
+				`.*on index 3: duplicate of item at index 0`),
+		)
+		t.Run("then the failure should hold the duplicate value",
+			vf.ErrorIs(subtest.FailGot("duplicate of item at index 0", 1)),
+		)
+	})
+	t.Run("given a list schema with a contains check", func(t *testing.T) {
+		c := subtest.ListSchema{Contains: subtest.DeepEqual(3)}
+		t.Run("then it should match a list containing a match",
+			subtest.Value([]int{1, 3}).Test(c),
+		)
+		t.Run("then a list without a match should result in an error",
+			subtest.Value(c.Check(subtest.Value([]int{1, 2}))).MatchPattern(
+				`(?s)^not matching list schema: .*does not match any elements`,
+			),
+		)
+	})
+	t.Run("given a non-list value", func(t *testing.T) {
+		c := subtest.ListSchema{}
+		t.Run("then it should fail",
+			subtest.Value(c.Check(subtest.Value("foo"))).ErrorIs(
+				subtest.FailGot("type is not slice or array", "foo"),
+			),
+		)
+	})
+}
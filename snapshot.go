@@ -0,0 +1,293 @@
+package subtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	msgSnapshotMatch   = "not matching snapshot"
+	msgSnapshotMissing = "missing snapshot; run with -update to create it"
+)
+
+// snapshotDoc holds all snapshots for a single test file.
+type snapshotDoc struct {
+	path    string
+	entries map[string]json.RawMessage
+	visited map[string]bool
+	skipped map[string]bool
+}
+
+var snapshots = struct {
+	sync.Mutex
+	docs  map[string]*snapshotDoc
+	calls map[*testing.T]int
+}{
+	docs:  make(map[string]*snapshotDoc),
+	calls: make(map[*testing.T]int),
+}
+
+// Snapshot returns a check function that compares the test value against the
+// snapshot stored for t. Snapshots are stored as JSON, keyed by t.Name(), in
+// one document per test file at testdata/snapshots/<file>.json relative to the
+// package directory. When Snapshot is called more than once for the same test,
+// the call number is appended to the key for the second call and onwards, e.g.
+// "TestFoo/bar (2)".
+//
+// The test file is resolved from the direct caller of Snapshot. When called
+// from a test helper, the snapshot is therefore stored in the document of the
+// file that declares the helper.
+//
+// When the flag -update is set to true, snapshots are written instead of
+// compared. The flag is registered by SnapshotMain, if not already defined.
+func Snapshot(t *testing.T) CheckFunc {
+	_, file, _, _ := runtime.Caller(1)
+	return snapshotCheck(t, file)
+}
+
+// Snapshot returns a test function that compares the test value against the
+// snapshot stored for the test. See the Snapshot function for details.
+func (vf ValueFunc) Snapshot() func(t *testing.T) {
+	_, file, _, _ := runtime.Caller(1)
+	return func(t *testing.T) {
+		t.Helper()
+
+		if err := snapshotCheck(t, file).Check(vf); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// SnapshotMain runs the tests in m and returns an exit code. Use it from
+// TestMain to enable detection of obsolete snapshots. When all tests are run
+// (no -run, -skip or -short flag), snapshots that are stored in a document used
+// by the run, but that are not checked by any test, are reported as obsolete,
+// and cause a non-zero exit code. In update mode, obsolete snapshots are
+// removed instead. Snapshots for tests that are skipped after the snapshot
+// check is created, including their sub-tests, are never obsolete. Tests that
+// are skipped before this point can not be detected; use the -short flag for
+// such skips to disable the detection.
+func SnapshotMain(m *testing.M) int {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update snapshots instead of comparing against them")
+	}
+
+	code := m.Run()
+	if code != 0 || isFlagSet("test.run") || isFlagSet("test.skip") || testing.Short() {
+		return code
+	}
+
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	for _, doc := range sortedSnapshotDocs() {
+		obsolete := doc.obsolete()
+		if len(obsolete) == 0 {
+			continue
+		}
+		if updateSnapshots() {
+			for _, key := range obsolete {
+				delete(doc.entries, key)
+			}
+			if err := doc.save(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s: obsolete snapshots; run with -update to remove them:\n\t%s\n",
+			doc.path, strings.Join(obsolete, "\n\t"),
+		)
+		code = 1
+	}
+	return code
+}
+
+func snapshotCheck(t *testing.T, file string) CheckFunc {
+	snapshots.Lock()
+	n := snapshots.calls[t] + 1
+	snapshots.calls[t] = n
+	snapshots.Unlock()
+
+	key := t.Name()
+	if n > 1 {
+		// Test names never contain spaces, so the key can't conflict with
+		// the name of another test.
+		key = fmt.Sprintf("%s (%d)", key, n)
+	}
+	path := filepath.Join(
+		"testdata", "snapshots",
+		strings.TrimSuffix(filepath.Base(file), ".go")+".json",
+	)
+	if abs, err := filepath.Abs(path); err == nil {
+		// Resolve the path while the test is running, in case the working
+		// directory is changed later.
+		path = abs
+	}
+	t.Cleanup(func() {
+		snapshots.Lock()
+		defer snapshots.Unlock()
+		delete(snapshots.calls, t)
+		if !t.Skipped() {
+			return
+		}
+		if doc, err := loadSnapshotDoc(path); err == nil {
+			doc.skipped[key] = true
+		}
+	})
+
+	return func(got interface{}) error {
+		if b, ok := got.([]byte); ok {
+			got = string(b)
+		}
+		gotJSON, err := json.Marshal(got)
+		if err != nil {
+			return FailGot(err.Error(), got)
+		}
+
+		snapshots.Lock()
+		defer snapshots.Unlock()
+
+		doc, err := loadSnapshotDoc(path)
+		if err != nil {
+			return err
+		}
+		doc.visited[key] = true
+
+		if updateSnapshots() {
+			doc.entries[key] = gotJSON
+			return doc.save()
+		}
+
+		expectJSON, ok := doc.entries[key]
+		if !ok {
+			return FailGot(msgSnapshotMissing, got)
+		}
+		gotV, err := decodeSnapshot(gotJSON)
+		if err != nil {
+			return FailGot(err.Error(), got)
+		}
+		expectV, err := decodeSnapshot(expectJSON)
+		if err != nil {
+			return fmt.Errorf("snapshot %s in %s: %w", key, path, err)
+		}
+		if !reflect.DeepEqual(gotV, expectV) {
+			fail := FailExpect(msgSnapshotMatch, gotV, expectV)
+			fail.Diff = FormatDiff(gotV, expectV)
+			return fail
+		}
+		return nil
+	}
+}
+
+// loadSnapshotDoc returns the document for path, loading it from disk on first
+// use. The caller must hold the snapshots lock.
+func loadSnapshotDoc(path string) (*snapshotDoc, error) {
+	if doc, ok := snapshots.docs[path]; ok {
+		return doc, nil
+	}
+
+	doc := &snapshotDoc{
+		path:    path,
+		entries: make(map[string]json.RawMessage),
+		visited: make(map[string]bool),
+		skipped: make(map[string]bool),
+	}
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("snapshot file: %w", err)
+	default:
+		if err := json.Unmarshal(b, &doc.entries); err != nil {
+			return nil, fmt.Errorf("snapshot file %s: %w", path, err)
+		}
+	}
+	snapshots.docs[path] = doc
+	return doc, nil
+}
+
+func (doc *snapshotDoc) obsolete() []string {
+	var keys []string
+	for key := range doc.entries {
+		if !doc.visited[key] && !doc.isSkipped(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isSkipped reports whether the test for key, or any of its parent tests, was
+// skipped after creating a snapshot check.
+func (doc *snapshotDoc) isSkipped(key string) bool {
+	for {
+		if doc.skipped[key] {
+			return true
+		}
+		i := strings.LastIndex(key, "/")
+		if i < 0 {
+			return false
+		}
+		key = key[:i]
+	}
+}
+
+func (doc *snapshotDoc) save() error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc.entries); err != nil {
+		return fmt.Errorf("snapshot file %s: %w", doc.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(doc.path), 0o755); err != nil {
+		return fmt.Errorf("snapshot file: %w", err)
+	}
+	if err := os.WriteFile(doc.path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("snapshot file: %w", err)
+	}
+	return nil
+}
+
+func sortedSnapshotDocs() []*snapshotDoc {
+	docs := make([]*snapshotDoc, 0, len(snapshots.docs))
+	for _, doc := range snapshots.docs {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].path < docs[j].path
+	})
+	return docs
+}
+
+func decodeSnapshot(b []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func updateSnapshots() bool {
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+func isFlagSet(name string) bool {
+	f := flag.Lookup(name)
+	return f != nil && f.Value.String() != ""
+}
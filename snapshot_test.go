@@ -0,0 +1,92 @@
+package subtest_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+// useSnapshotDir changes the working directory to a temporary directory with
+// update mode disabled until t completes. Unless entries is nil, it's stored
+// as the snapshot document for this file. This allows testing failures
+// without relying on the committed snapshots.
+func useSnapshotDir(t *testing.T, entries map[string]interface{}) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if entries != nil {
+		b, err := json.Marshal(entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "testdata", "snapshots", "snapshot_test.json")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	update := flag.Lookup("update").Value.String()
+	if err := flag.Set("update", "false"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { flag.Set("update", update) })
+}
+
+func TestSnapshot(t *testing.T) {
+	type T struct {
+		Name  string
+		Price int
+	}
+
+	t.Run("given a string value", subtest.Value("foo\nbar").Snapshot())
+	t.Run("given a struct value", subtest.Value(T{Name: "foo", Price: 42}).Snapshot())
+	t.Run("given a []byte value", func(t *testing.T) {
+		vf := subtest.Value([]byte(`raw`))
+		t.Run("then it should be stored as a string", vf.Test(subtest.Snapshot(t)))
+	})
+	t.Run("given two snapshot checks in the same test", func(t *testing.T) {
+		t.Run("then the first value should match its snapshot", subtest.Value("foo").Test(subtest.Snapshot(t)))
+		t.Run("then the second value should match its snapshot", subtest.Value("bar").Test(subtest.Snapshot(t)))
+	})
+	t.Run("given a test skipped after creating the snapshot check", func(t *testing.T) {
+		subtest.Snapshot(t)
+		t.Skip("the snapshot should not be reported as obsolete")
+	})
+	t.Run("given a value not matching the snapshot", func(t *testing.T) {
+		useSnapshotDir(t, map[string]interface{}{
+			t.Name(): T{Name: "foo", Price: 42},
+		})
+		c := subtest.Snapshot(t)
+		vf := subtest.Value(c.Check(subtest.Value(T{Name: "foo", Price: 41})))
+		expect := subtest.FailExpect("not matching snapshot",
+			map[string]interface{}{"Name": "foo", "Price": json.Number("41")},
+			map[string]interface{}{"Name": "foo", "Price": json.Number("42")},
+		)
+		expect.Diff = `$.Price: got "41", want "42"`
+		t.Run("then it should fail", vf.ErrorIs(expect))
+	})
+	t.Run("given a test without a snapshot", func(t *testing.T) {
+		useSnapshotDir(t, nil)
+		c := subtest.Snapshot(t)
+		vf := subtest.Value(c.Check(subtest.Value("foo")))
+		t.Run("then it should fail",
+			vf.ErrorIs(subtest.FailGot("missing snapshot; run with -update to create it", "foo")),
+		)
+	})
+}
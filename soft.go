@@ -0,0 +1,71 @@
+package subtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestSoft returns a test function that fails with the error returned by
+// c.Check(vf) via t.Error. Unlike Test, the test function is not stopped on
+// failure.
+func (vf ValueFunc) TestSoft(c Check) func(t *testing.T) {
+	return func(t *testing.T) {
+		t.Helper()
+
+		if err := c.Check(vf); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// SoftCollector allows running several independent checks within the same
+// test function, recording failures instead of aborting the test.
+type SoftCollector struct {
+	t *testing.T
+
+	mu     sync.Mutex
+	total  int
+	failed []string
+}
+
+// Soft returns a new SoftCollector for t. When one or more checks fail, a
+// summary of all failing checks is logged to t at the end of the test.
+func Soft(t *testing.T) *SoftCollector {
+	t.Helper()
+	s := &SoftCollector{t: t}
+	t.Cleanup(s.summary)
+	return s
+}
+
+// Test runs c against vf, and report any error via t.Error, prefixed by name.
+// It reports whether the check passed.
+func (s *SoftCollector) Test(name string, vf ValueFunc, c Check) bool {
+	s.t.Helper()
+
+	err := c.Check(vf)
+
+	s.mu.Lock()
+	s.total++
+	if err != nil {
+		s.failed = append(s.failed, name)
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		s.t.Error(fmt.Errorf("%s: %w", name, err))
+		return false
+	}
+	return true
+}
+
+func (s *SoftCollector) summary() {
+	s.t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.failed) > 0 {
+		s.t.Logf("%d of %d check(s) failed:\n%s", len(s.failed), s.total, strings.Join(s.failed, "\n"))
+	}
+}
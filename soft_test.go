@@ -0,0 +1,25 @@
+package subtest_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func TestTestSoft(t *testing.T) {
+	t.Run("given a passing check", func(t *testing.T) {
+		t.Run("then it should pass", subtest.Value(42).TestSoft(subtest.DeepEqual(42)))
+	})
+}
+
+func TestSoft(t *testing.T) {
+	t.Run("given a soft collector with passing checks", func(t *testing.T) {
+		s := subtest.Soft(t)
+		ok1 := s.Test("v > 41", subtest.Value(42), subtest.GreaterThan(41))
+		ok2 := s.Test("v < 43", subtest.Value(42), subtest.LessThan(43))
+
+		t.Run("then all checks should report success",
+			subtest.Value([]bool{ok1, ok2}).DeepEqual([]bool{true, true}),
+		)
+	})
+}
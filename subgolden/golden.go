@@ -0,0 +1,210 @@
+// Package subgolden contains checks that compare test values against the
+// content of golden files. Valid input values for all checks are string,
+// []byte and json.RawMessage.
+//
+// When tests are run with the -update flag, golden files are written with the
+// content of the test value instead of being compared. The flag is looked up
+// when checks are run. As test packages may define their own -update flag, it's
+// not registered on import. Call RegisterUpdateFlag, or subtest.SnapshotMain,
+// from TestMain to register it:
+//
+//	func TestMain(m *testing.M) {
+//		subgolden.RegisterUpdateFlag()
+//		os.Exit(m.Run())
+//	}
+package subgolden
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/clarify/subtest"
+)
+
+const (
+	msgNotBytesType = "type is not string, []byte or json.RawMessage"
+	msgGoldenMatch  = "not matching golden file"
+
+	// maxDiffCells limits the size of the table used for computing line diffs.
+	maxDiffCells = 1 << 20
+)
+
+// RegisterUpdateFlag registers the -update flag on the command-line flag set,
+// unless a flag with the same name is already defined. It must be called before
+// flags are parsed, e.g. from TestMain.
+func RegisterUpdateFlag() {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update golden files instead of comparing against them")
+	}
+}
+
+// Golden returns a check function that compares the test value against the
+// content of the golden file at path.
+func Golden(path string) subtest.CheckFunc {
+	return func(got interface{}) error {
+		b, err := asBytes(got)
+		if err != nil {
+			return err
+		}
+		return compare(path, b)
+	}
+}
+
+// GoldenJSON returns a check function that compares the test value against
+// the content of the golden file at path after both are canonicalized as
+// indented JSON with sorted object keys.
+func GoldenJSON(path string) subtest.CheckFunc {
+	return func(got interface{}) error {
+		b, err := asBytes(got)
+		if err != nil {
+			return err
+		}
+		b, err = canonicalJSON(b)
+		if err != nil {
+			return subtest.FailGot(err.Error(), got)
+		}
+		if !updateGolden() {
+			expect, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("golden file: %w", err)
+			}
+			if expect, err = canonicalJSON(expect); err != nil {
+				return fmt.Errorf("golden file %s: %w", path, err)
+			}
+			return compareBytes(path, b, expect)
+		}
+		return compare(path, b)
+	}
+}
+
+func asBytes(v interface{}) ([]byte, error) {
+	switch vt := v.(type) {
+	case []byte:
+		return vt, nil
+	case json.RawMessage:
+		return vt, nil
+	case string:
+		return []byte(vt), nil
+	default:
+		return nil, subtest.FailGot(msgNotBytesType, v)
+	}
+}
+
+// compare compares got against the content of the golden file at path, or
+// writes got to path when the -update flag is set.
+func compare(path string, got []byte) error {
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("golden file: %w", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			return fmt.Errorf("golden file: %w", err)
+		}
+		return nil
+	}
+	expect, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("golden file: %w", err)
+	}
+	return compareBytes(path, got, expect)
+}
+
+func updateGolden() bool {
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+func compareBytes(path string, got, expect []byte) error {
+	if bytes.Equal(got, expect) {
+		return nil
+	}
+	fail := subtest.FailExpect(msgGoldenMatch+" "+path, string(got), string(expect))
+	fail.Diff = lineDiff(got, expect)
+	return fail
+}
+
+// lineDiff returns a diff of the lines in got and expect, based on their
+// longest common subsequence. Lines only in expect are listed with a "-" prefix
+// and their line number in expect. Lines only in got are listed with a "+"
+// prefix and their line number in got.
+func lineDiff(got, expect []byte) string {
+	a, b := lines(expect), lines(got)
+
+	// Trim common lines at both ends to reduce the size of the table.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	a, b = a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	// lcs[i][j] holds the length of the longest common subsequence of a[i:]
+	// and b[j:]. For very large inputs, the table is skipped, and all
+	// remaining lines in a are listed before the ones in b.
+	var lcs [][]int
+	if len(a)*len(b) <= maxDiffCells {
+		lcs = make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				switch {
+				case a[i] == b[j]:
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				case lcs[i+1][j] >= lcs[i][j+1]:
+					lcs[i][j] = lcs[i+1][j]
+				default:
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || i < len(a) && (lcs == nil || lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, fmt.Sprintf("-%d: %q", pre+i+1, a[i]))
+			i++
+		default:
+			out = append(out, fmt.Sprintf("+%d: %q", pre+j+1, b[j]))
+			j++
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func lines(b []byte) []string {
+	return strings.Split(string(b), "\n")
+}
+
+func canonicalJSON(b []byte) ([]byte, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
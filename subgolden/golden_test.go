@@ -0,0 +1,119 @@
+package subgolden_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/subgolden"
+)
+
+// update is defined by the test package, to verify that subgolden does not
+// conflict with it.
+var update = flag.Bool("update", false, "update golden files in the test package")
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	t.Run("given a golden file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "golden.txt")
+		writeFile(t, path, "foo\nbar\n")
+		c := subgolden.Golden(path)
+
+		t.Run("when checking a matching string", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value("foo\nbar\n").Test(c))
+		})
+		t.Run("when checking a matching []byte", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value([]byte("foo\nbar\n")).Test(c))
+		})
+		t.Run("when checking a different string", func(t *testing.T) {
+			err := c.Check(subtest.Value("foo\nbaz\n"))
+			expect := subtest.FailExpect("not matching golden file "+path, "foo\nbaz\n", "foo\nbar\n")
+			expect.Diff = "-2: \"bar\"\n+2: \"baz\""
+			t.Run("then it should fail with a line diff", subtest.Value(err).ErrorIs(expect))
+		})
+		t.Run("when checking a string with an inserted line", func(t *testing.T) {
+			err := c.Check(subtest.Value("foo\nnew\nbar\n"))
+			expect := subtest.FailExpect("not matching golden file "+path, "foo\nnew\nbar\n", "foo\nbar\n")
+			expect.Diff = `+2: "new"`
+			t.Run("then only the inserted line should be reported", subtest.Value(err).ErrorIs(expect))
+		})
+		t.Run("when checking a string with a removed line", func(t *testing.T) {
+			err := c.Check(subtest.Value("bar\n"))
+			expect := subtest.FailExpect("not matching golden file "+path, "bar\n", "foo\nbar\n")
+			expect.Diff = `-1: "foo"`
+			t.Run("then only the removed line should be reported", subtest.Value(err).ErrorIs(expect))
+		})
+		t.Run("when checking an int", func(t *testing.T) {
+			err := c.Check(subtest.Value(42))
+			t.Run("then it should fail", subtest.Value(err).ErrorIs(
+				subtest.FailGot("type is not string, []byte or json.RawMessage", 42),
+			))
+		})
+	})
+	t.Run("given a missing golden file", func(t *testing.T) {
+		c := subgolden.Golden(filepath.Join(t.TempDir(), "missing.txt"))
+		t.Run("then it should fail", subtest.Value(c.Check(subtest.Value("foo"))).MatchPattern(
+			`^golden file: open .*missing.txt: no such file or directory$`,
+		))
+	})
+}
+
+func TestGoldenJSON(t *testing.T) {
+	t.Run("given a golden JSON file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "golden.json")
+		writeFile(t, path, `{"b": [1, 2], "a": "x"}`)
+		c := subgolden.GoldenJSON(path)
+
+		t.Run("when checking equivalent JSON with a different formatting", func(t *testing.T) {
+			v := json.RawMessage(`{"a":"x","b":[1,2]}`)
+			t.Run("then it should pass", subtest.Value(v).Test(c))
+		})
+		t.Run("when checking different JSON", func(t *testing.T) {
+			err := c.Check(subtest.Value(`{"a":"y","b":[1,2]}`))
+			t.Run("then it should fail",
+				subtest.Value(err).MatchPattern(`^not matching golden file .*golden.json\n`),
+			)
+		})
+	})
+}
+
+func TestRegisterUpdateFlag(t *testing.T) {
+	t.Run("given the update flag is defined by the test package", func(t *testing.T) {
+		t.Run("when registering the update flag", func(t *testing.T) {
+			subgolden.RegisterUpdateFlag()
+			t.Run("then the existing flag should be kept", subtest.Value(flag.Lookup("update").Usage).DeepEqual(
+				"update golden files in the test package",
+			))
+		})
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	*update = true
+	t.Cleanup(func() { *update = false })
+
+	t.Run("given the update flag is set", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "testdata", "golden.json")
+
+		t.Run("when checking JSON against a missing file",
+			subtest.Value(`{"b":1,"a":2}`).Test(subgolden.GoldenJSON(path)),
+		)
+		t.Run("then the file should hold canonicalized JSON", func(t *testing.T) {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			subtest.Value(string(b)).DeepEqual("{\n  \"a\": 2,\n  \"b\": 1\n}\n")(t)
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package subjson
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/clarify/subtest"
@@ -12,7 +13,7 @@ func OnString(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(String(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded string: %w", err)
+			return decoderError("JSON decoded string", err)
 		}
 		return nil
 	}
@@ -24,7 +25,7 @@ func OnNumber(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Number(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded number: %w", err)
+			return decoderError("JSON decoded number", err)
 		}
 		return nil
 	}
@@ -36,7 +37,7 @@ func OnInt64(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Int64(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded int64: %w", err)
+			return decoderError("JSON decoded int64", err)
 		}
 		return nil
 	}
@@ -48,7 +49,7 @@ func OnFloat64(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Float64(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded float64: %w", err)
+			return decoderError("JSON decoded float64", err)
 		}
 		return nil
 	}
@@ -60,7 +61,7 @@ func OnSlice(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Slice(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded slice: %w", err)
+			return decoderError("JSON decoded slice", err)
 		}
 		return nil
 	}
@@ -72,7 +73,7 @@ func OnMap(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Map(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded map: %w", err)
+			return decoderError("JSON decoded map", err)
 		}
 		return nil
 	}
@@ -84,7 +85,7 @@ func OnTime(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Time(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded time: %w", err)
+			return decoderError("JSON decoded time", err)
 		}
 		return nil
 	}
@@ -96,8 +97,77 @@ func OnInterface(c subtest.Check) subtest.CheckFunc {
 	return func(got interface{}) error {
 		err := c.Check(Interface(got))
 		if err != nil {
-			return fmt.Errorf("on JSON decoded value: %w", err)
+			return decoderError("JSON decoded value", err)
 		}
 		return nil
 	}
 }
+
+// OnPointer returns a check function where the value at the RFC 6901 JSON
+// Pointer p is extracted from the test value as a json.RawMessage before it's
+// passed to c. Only the values along the pointer are decoded.
+func OnPointer(p string, c subtest.Check) subtest.CheckFunc {
+	return func(got interface{}) error {
+		raw, path, err := resolvePointer(got, p)
+		if err != nil {
+			return err
+		}
+		if err := c.Check(subtest.Value(raw)); err != nil {
+			return subtest.PathError{
+				Prefix: fmt.Sprintf("on JSON pointer %q", p),
+				Path:   path,
+				Err:    err,
+			}
+		}
+		return nil
+	}
+}
+
+// OnPath returns a check function where all values matching the JSONPath
+// expression p are extracted from the test value as json.RawMessage values,
+// and passed to c one by one. The check fails if no values match p. Supported
+// syntax is limited to the root selector `$`, child selectors `.name`,
+// `['name']` and `["name"]`, index selectors `[0]` and wildcard selectors `.*`
+// and `[*]`.
+func OnPath(p string, c subtest.Check) subtest.CheckFunc {
+	return func(got interface{}) error {
+		segs, err := parseJSONPath(p)
+		if err != nil {
+			return err
+		}
+		var raw json.RawMessage
+		if err := unmarshalJSON(got, &raw); err != nil {
+			return err
+		}
+
+		matches := matchJSONPath(nil, nil, raw, segs)
+		if len(matches) == 0 {
+			return subtest.FailGot(fmt.Sprintf("JSONPath %q: no matching values", p), got)
+		}
+
+		var errs subtest.Errors
+		for _, m := range matches {
+			if err := c.Check(subtest.Value(m.raw)); err != nil {
+				errs = append(errs, subtest.PathError{
+					Prefix: fmt.Sprintf("at %s", m.path),
+					Path:   m.path,
+					Err:    err,
+				})
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("on JSONPath %q: %w", p, errs)
+		}
+		return nil
+	}
+}
+
+// decoderError returns an error prefixed by "on <name>". The name is recorded
+// as a path element of kind subtest.PathDecoder.
+func decoderError(name string, err error) error {
+	return subtest.PathError{
+		Prefix: "on " + name,
+		Path:   subtest.Path{{Kind: subtest.PathDecoder, Name: name}},
+		Err:    err,
+	}
+}
@@ -0,0 +1,217 @@
+package subjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/clarify/subtest"
+)
+
+const (
+	msgEqual      = "not equal JSON"
+	msgSubsetOf   = "not a subset of expected JSON"
+	msgSupersetOf = "not a superset of expected JSON"
+
+	// jsonNone is used in place of a value for missing keys and elements.
+	jsonNone = "<none>"
+)
+
+type compareMode int
+
+const (
+	modeEqual compareMode = iota
+	modeSubset
+	modeSuperset
+)
+
+// Equal returns a check function that fails if the test value is not
+// semantically equivalent to the expected JSON. Key order, white-space and
+// number representation are not significant. Both the test value and expect
+// must be of type string, []byte or json.RawMessage.
+func Equal(expect interface{}) subtest.CheckFunc {
+	return compareCheck(expect, modeEqual, msgEqual)
+}
+
+// SubsetOf returns a check function that fails if the test value is not a
+// semantic subset of the expected JSON. I.e. all object keys in the test value
+// must be present in expect, recursively. Array elements are compared by
+// position, so a test value array must be a prefix of the expected array.
+func SubsetOf(expect interface{}) subtest.CheckFunc {
+	return compareCheck(expect, modeSubset, msgSubsetOf)
+}
+
+// SupersetOf returns a check function that fails if the test value is not a
+// semantic superset of the expected JSON. I.e. all object keys in expect must
+// be present in the test value, recursively. Array elements are compared by
+// position, so the expected array must be a prefix of a test value array.
+func SupersetOf(expect interface{}) subtest.CheckFunc {
+	return compareCheck(expect, modeSuperset, msgSupersetOf)
+}
+
+func compareCheck(expect interface{}, mode compareMode, msg string) subtest.CheckFunc {
+	return func(got interface{}) error {
+		ev, err := decodeJSON(expect)
+		if err != nil {
+			return fmt.Errorf("expect value: %w", err)
+		}
+		gv, err := decodeJSON(got)
+		if err != nil {
+			return err
+		}
+
+		var c jsonComparer
+		c.compare(nil, gv, ev, mode)
+		if len(c.lines) == 0 {
+			return nil
+		}
+		fail := subtest.FailExpect(msg, got, expect)
+		fail.Diff = strings.Join(c.lines, "\n")
+		return fail
+	}
+}
+
+// decodeJSON decodes v into an interface{} value, using json.Number for all
+// numbers.
+func decodeJSON(v interface{}) (interface{}, error) {
+	var b []byte
+	switch vt := v.(type) {
+	case []byte:
+		b = vt
+	case json.RawMessage:
+		b = vt
+	case string:
+		b = []byte(vt)
+	default:
+		return nil, subtest.FailGot("type is not JSON decodable", v)
+	}
+
+	var t interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&t); err != nil {
+		return nil, subtest.FailGot(err.Error(), v)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, subtest.FailGot("invalid character after top-level value", v)
+	}
+	return t, nil
+}
+
+type jsonComparer struct {
+	lines []string
+}
+
+func (c *jsonComparer) report(path subtest.Path, got, expect string) {
+	c.lines = append(c.lines, fmt.Sprintf("%s: got %s, want %s", path, got, expect))
+}
+
+func (c *jsonComparer) compare(path subtest.Path, got, expect interface{}, mode compareMode) {
+	switch gt := got.(type) {
+	case map[string]interface{}:
+		et, ok := expect.(map[string]interface{})
+		if !ok {
+			break
+		}
+		c.compareObject(path, gt, et, mode)
+		return
+	case []interface{}:
+		et, ok := expect.([]interface{})
+		if !ok {
+			break
+		}
+		c.compareArray(path, gt, et, mode)
+		return
+	case json.Number:
+		if et, ok := expect.(json.Number); ok && equalNumber(gt, et) {
+			return
+		}
+	default:
+		// string, bool or nil.
+		if got == expect {
+			return
+		}
+	}
+	c.report(path, formatJSON(got), formatJSON(expect))
+}
+
+func (c *jsonComparer) compareObject(path subtest.Path, got, expect map[string]interface{}, mode compareMode) {
+	keys := make([]string, 0, len(got)+len(expect))
+	for k := range got {
+		keys = append(keys, k)
+	}
+	for k := range expect {
+		if _, ok := got[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		kPath := appendPath(path, subtest.PathElement{Kind: subtest.PathKey, Key: k})
+		gv, gok := got[k]
+		ev, eok := expect[k]
+		switch {
+		case !eok && mode != modeSuperset:
+			c.report(kPath, formatJSON(gv), jsonNone)
+		case !gok && mode != modeSubset:
+			c.report(kPath, jsonNone, formatJSON(ev))
+		case gok && eok:
+			c.compare(kPath, gv, ev, mode)
+		}
+	}
+}
+
+func (c *jsonComparer) compareArray(path subtest.Path, got, expect []interface{}, mode compareMode) {
+	n := len(got)
+	if len(expect) > n {
+		n = len(expect)
+	}
+	for i := 0; i < n; i++ {
+		iPath := appendPath(path, subtest.PathElement{Kind: subtest.PathIndex, Index: i})
+		switch {
+		case i >= len(expect):
+			if mode != modeSuperset {
+				c.report(iPath, formatJSON(got[i]), jsonNone)
+			}
+		case i >= len(got):
+			if mode != modeSubset {
+				c.report(iPath, jsonNone, formatJSON(expect[i]))
+			}
+		default:
+			c.compare(iPath, got[i], expect[i], mode)
+		}
+	}
+}
+
+func appendPath(path subtest.Path, e subtest.PathElement) subtest.Path {
+	return append(path[:len(path):len(path)], e)
+}
+
+func equalNumber(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	var ra, rb big.Rat
+	if _, ok := ra.SetString(string(a)); !ok {
+		return false
+	}
+	if _, ok := rb.SetString(string(b)); !ok {
+		return false
+	}
+	return ra.Cmp(&rb) == 0
+}
+
+func formatJSON(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,128 @@
+package subjson_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/subjson"
+)
+
+func compareFailure(msg, got, expect, diff string) subtest.Failure {
+	f := subtest.FailExpect(msg, got, expect)
+	f.Diff = diff
+	return f
+}
+
+func TestEqual(t *testing.T) {
+	t.Run("given a check Equal(`1`)", func(t *testing.T) {
+		cf := subjson.Equal(`1`)
+		t.Run("when checking against `1.0 `", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value(`1.0 `).Test(cf))
+		})
+		t.Run("when checking against `1]`", func(t *testing.T) {
+			vf := subtest.Value(cf(`1]`))
+			t.Run("then it should fail", vf.ErrorIs(
+				subtest.FailGot("invalid character after top-level value", `1]`),
+			))
+		})
+		t.Run("when checking against `1}`", func(t *testing.T) {
+			vf := subtest.Value(cf(`1}`))
+			t.Run("then it should fail", vf.ErrorIs(
+				subtest.FailGot("invalid character after top-level value", `1}`),
+			))
+		})
+		t.Run("when checking against `1 2`", func(t *testing.T) {
+			vf := subtest.Value(cf(`1 2`))
+			t.Run("then it should fail", vf.ErrorIs(
+				subtest.FailGot("invalid character after top-level value", `1 2`),
+			))
+		})
+	})
+	t.Run("given a check Equal(`1]`)", func(t *testing.T) {
+		cf := subjson.Equal(`1]`)
+		t.Run("when checking against `1`", func(t *testing.T) {
+			vf := subtest.Value(cf(`1`))
+			t.Run("then it should fail on the expect value",
+				vf.MatchPattern("^expect value: invalid character after top-level value"),
+			)
+		})
+	})
+}
+
+func TestSubsetOf(t *testing.T) {
+	const msg = "not a subset of expected JSON"
+
+	t.Run("given a check SubsetOf(`{\"a\":1,\"b\":[1,2]}`)", func(t *testing.T) {
+		const expect = `{"a":1,"b":[1,2]}`
+		cf := subjson.SubsetOf(expect)
+		t.Run("when checking against a subset", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value(`{"b":[1]}`).Test(cf))
+		})
+		t.Run("when checking against an object with an extra key", func(t *testing.T) {
+			const v = `{"a":1,"c":true}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.c: got true, want <none>`),
+			))
+		})
+		t.Run("when checking against an array with an extra element", func(t *testing.T) {
+			const v = `{"b":[1,2,3]}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.b[2]: got 3, want <none>`),
+			))
+		})
+		t.Run("when checking against an array with a different element", func(t *testing.T) {
+			const v = `{"b":[2]}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.b[0]: got 2, want 1`),
+			))
+		})
+		t.Run("when checking against values of mismatched types", func(t *testing.T) {
+			const v = `{"a":"1","b":{}}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.a: got "1", want 1`+"\n"+`$.b: got {}, want [1,2]`),
+			))
+		})
+		t.Run("when checking against an array", func(t *testing.T) {
+			const v = `[1]`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$: got [1], want {"a":1,"b":[1,2]}`),
+			))
+		})
+	})
+}
+
+func TestSupersetOf(t *testing.T) {
+	const msg = "not a superset of expected JSON"
+
+	t.Run("given a check SupersetOf(`{\"a\":1,\"b\":[1,2]}`)", func(t *testing.T) {
+		const expect = `{"a":1,"b":[1,2]}`
+		cf := subjson.SupersetOf(expect)
+		t.Run("when checking against a superset", func(t *testing.T) {
+			t.Run("then it should pass", subtest.Value(`{"a":1.0,"b":[1,2,3],"c":true}`).Test(cf))
+		})
+		t.Run("when checking against an object with a missing key", func(t *testing.T) {
+			const v = `{"a":1}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.b: got <none>, want [1,2]`),
+			))
+		})
+		t.Run("when checking against an array with a missing element", func(t *testing.T) {
+			const v = `{"a":1,"b":[1]}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.b[1]: got <none>, want 2`),
+			))
+		})
+		t.Run("when checking against values of mismatched types", func(t *testing.T) {
+			const v = `{"a":null,"b":"[1,2]"}`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$.a: got null, want 1`+"\n"+`$.b: got "[1,2]", want [1,2]`),
+			))
+		})
+		t.Run("when checking against a string", func(t *testing.T) {
+			const v = `"a"`
+			t.Run("then it should fail", subtest.Value(cf(v)).ErrorIs(
+				compareFailure(msg, v, expect, `$: got "a", want {"a":1,"b":[1,2]}`),
+			))
+		})
+	})
+}
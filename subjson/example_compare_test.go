@@ -0,0 +1,83 @@
+package subjson_test
+
+import (
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/subjson"
+)
+
+func ExampleEqual() {
+	const v = `{"foo": "A", "bar": [1, 2.0]}`
+
+	t.Run("v match expect", subtest.Value(v).Test(
+		subjson.Equal(`{"bar":[1.0,2e0],"foo":"A"}`),
+	))
+	// Output:
+	// === RUN   ParentTest/v_match_expect
+	// --- PASS: ParentTest/v_match_expect (0.00s)
+}
+
+func ExampleEqual_failingTest() {
+	const v = `{"foo": "A", "bar": "C", "items": [{"price": 10}]}`
+
+	t.Run("v match expect", subtest.Value(v).Test(
+		subjson.Equal(`{"foo": "A", "bar": "B", "baz": true, "items": [{"price": 12}]}`),
+	))
+	// FIXME: t.Helper issue causes return of value.go:131 instead of this file.
+	// Could be related to https://github.com/golang/go/issues/23249.
+
+	// Output:
+	// === RUN   ParentTest/v_match_expect
+	//     value.go:131: not equal JSON
+	//         got: string
+	//             "{\"foo\": \"A\", \"bar\": \"C\", \"items\": [{\"price\": 10}]}"
+	//         want: string
+	//             "{\"foo\": \"A\", \"bar\": \"B\", \"baz\": true, \"items\": [{\"price\": 12}]}"
+	//         diff:
+	//             $.bar: got "C", want "B"
+	//             $.baz: got <none>, want true
+	//             $.items[0].price: got 10, want 12
+	// --- FAIL: ParentTest/v_match_expect (0.00s)
+}
+
+func ExampleSubsetOf() {
+	const v = `{"foo": "A"}`
+
+	t.Run("v is a subset of expect", subtest.Value(v).Test(
+		subjson.SubsetOf(`{"foo": "A", "bar": "B"}`),
+	))
+	// Output:
+	// === RUN   ParentTest/v_is_a_subset_of_expect
+	// --- PASS: ParentTest/v_is_a_subset_of_expect (0.00s)
+}
+
+func ExampleSupersetOf() {
+	const v = `{"foo": "A", "bar": "B"}`
+
+	t.Run("v is a superset of expect", subtest.Value(v).Test(
+		subjson.SupersetOf(`{"foo": "A"}`),
+	))
+	// Output:
+	// === RUN   ParentTest/v_is_a_superset_of_expect
+	// --- PASS: ParentTest/v_is_a_superset_of_expect (0.00s)
+}
+
+func ExampleSupersetOf_failingTest() {
+	const v = `{"foo": "A", "bar": "B"}`
+
+	t.Run("v is a superset of expect", subtest.Value(v).Test(
+		subjson.SupersetOf(`{"foo": "A", "baz": "C"}`),
+	))
+	// FIXME: t.Helper issue causes return of value.go:131 instead of this file.
+	// Could be related to https://github.com/golang/go/issues/23249.
+
+	// Output:
+	// === RUN   ParentTest/v_is_a_superset_of_expect
+	//     value.go:131: not a superset of expected JSON
+	//         got: string
+	//             "{\"foo\": \"A\", \"bar\": \"B\"}"
+	//         want: string
+	//             "{\"foo\": \"A\", \"baz\": \"C\"}"
+	//         diff:
+	//             $.baz: got <none>, want "C"
+	// --- FAIL: ParentTest/v_is_a_superset_of_expect (0.00s)
+}
@@ -24,6 +24,8 @@ func ExampleMap_failingTest() {
 	//             map[bar:[34 98 97 122 34] foo:[34 98 97 114 34]]
 	//         want: map[string]json.RawMessage
 	//             map[bar:[34 102 111 111 98 97 114 34] foo:[34 98 97 114 34]]
+	//         diff:
+	//             $.bar: got `"baz"`, want `"foobar"`
 	// --- FAIL: ParentTest/v_match_cf (0.00s)
 }
 
@@ -47,6 +49,8 @@ func ExampleOnMap_failingTest() {
 	//             map[bar:[34 98 97 122 34] foo:[34 98 97 114 34]]
 	//         want: map[string]json.RawMessage
 	//             map[bar:[34 102 111 111 98 97 114 34] foo:[34 98 97 114 34]]
+	//         diff:
+	//             $.bar: got `"baz"`, want `"foobar"`
 	// --- FAIL: ParentTest/v_match_cf (0.00s)
 }
 
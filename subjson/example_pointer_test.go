@@ -0,0 +1,94 @@
+package subjson_test
+
+import (
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/subjson"
+)
+
+func ExampleOnPointer() {
+	const v = `{"data": {"items": [{"id": "a/1"}, {"id": "b/2"}]}}`
+
+	t.Run("v match cf", subtest.Value(v).Test(
+		subjson.OnPointer("/data/items/1/id", subjson.DecodesTo("b/2")),
+	))
+	// Output:
+	// === RUN   ParentTest/v_match_cf
+	// --- PASS: ParentTest/v_match_cf (0.00s)
+}
+
+func ExampleOnPointer_failingTest() {
+	const v = `{"data": {"items": [{"id": "a/1"}, {"id": "b/2"}]}}`
+
+	t.Run("v match cf", subtest.Value(v).Test(
+		subjson.OnPointer("/data/items/0/id", subjson.DecodesTo("b/2")),
+	))
+	// FIXME: t.Helper issue causes return of value.go:131 instead of this file.
+	// Could be related to https://github.com/golang/go/issues/23249.
+
+	// Output:
+	// === RUN   ParentTest/v_match_cf
+	//     value.go:131: on JSON pointer "/data/items/0/id": on JSON decoded value: not deep equal
+	//         got: string
+	//             "a/1"
+	//         want: string
+	//             "b/2"
+	// --- FAIL: ParentTest/v_match_cf (0.00s)
+}
+
+func ExampleOnPointer_missingKey() {
+	const v = `{"data": {"items": [{"id": "a/1"}, {"id": "b/2"}]}}`
+
+	t.Run("v match cf", subtest.Value(v).Test(
+		subjson.OnPointer("/data/items/0/name", subtest.Any()),
+	))
+	// FIXME: t.Helper issue causes return of value.go:131 instead of this file.
+	// Could be related to https://github.com/golang/go/issues/23249.
+
+	// Output:
+	// === RUN   ParentTest/v_match_cf
+	//     value.go:131: JSON pointer "/data/items/0/name": key "name" not found at $.data.items[0]
+	// --- FAIL: ParentTest/v_match_cf (0.00s)
+}
+
+func ExamplePointer() {
+	const v = `{"a~b": {"c/d": 42}}`
+
+	t.Run("v match cf", subjson.Pointer(v, "/a~0b/c~1d").Test(subjson.NumericEqual(42)))
+	// Output:
+	// === RUN   ParentTest/v_match_cf
+	// --- PASS: ParentTest/v_match_cf (0.00s)
+}
+
+func ExampleOnPath() {
+	const v = `{"data": {"items": [{"id": 1}, {"id": 2}, {"id": 3}]}}`
+
+	t.Run("v match cf", subtest.Value(v).Test(
+		subjson.OnPath("$.data.items[*].id", subjson.GreaterThan(0)),
+	))
+	// Output:
+	// === RUN   ParentTest/v_match_cf
+	// --- PASS: ParentTest/v_match_cf (0.00s)
+}
+
+func ExampleOnPath_failingTest() {
+	const v = `{"data": {"items": [{"id": 1}, {"id": 2}, {"id": 3}]}}`
+
+	t.Run("v match cf", subtest.Value(v).Test(
+		subjson.OnPath("$.data.items[*]['id']", subjson.LessThan(2)),
+	))
+	// FIXME: t.Helper issue causes return of value.go:131 instead of this file.
+	// Could be related to https://github.com/golang/go/issues/23249.
+
+	// Output:
+	// === RUN   ParentTest/v_match_cf
+	//     value.go:131: on JSONPath "$.data.items[*]['id']": 2 issue(s)
+	//         issue #0:
+	//             at $.data.items[1].id: on JSON decoded number: not less than 2.000000
+	//             got: json.Number
+	//                 "2"
+	//         issue #1:
+	//             at $.data.items[2].id: on JSON decoded number: not less than 2.000000
+	//             got: json.Number
+	//                 "3"
+	// --- FAIL: ParentTest/v_match_cf (0.00s)
+}
@@ -0,0 +1,68 @@
+package subjson_test
+
+import (
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/subjson"
+)
+
+const orderSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["id", "items"],
+	"properties": {
+		"id": {"type": "string", "pattern": "^o-[0-9]+$"},
+		"status": {"enum": ["open", "closed"]},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {"$ref": "#/$defs/item"}
+		}
+	},
+	"additionalProperties": false,
+	"$defs": {
+		"item": {
+			"type": "object",
+			"required": ["price"],
+			"properties": {
+				"price": {"type": "number", "minimum": 0},
+				"parts": {"type": "array", "items": {"$ref": "#/$defs/item"}}
+			}
+		}
+	}
+}`
+
+func ExampleCompileSchema() {
+	const v = `{"id": "o-42", "status": "open", "items": [{"price": 10, "parts": [{"price": 0}]}]}`
+	c := subjson.MustCompileSchema(orderSchema)
+
+	t.Run("v match schema", subtest.Value(v).Test(c))
+	// Output:
+	// === RUN   ParentTest/v_match_schema
+	// --- PASS: ParentTest/v_match_schema (0.00s)
+}
+
+func ExampleCompileSchema_failingTest() {
+	const v = `{"id": "o-42", "status": "pending", "items": [{"price": 10, "parts": [{"price": -1}]}]}`
+	c := subjson.MustCompileSchema(orderSchema)
+
+	t.Run("v match schema", subtest.Value(v).Test(c))
+	// FIXME: t.Helper issue causes return of value.go:131 instead of this file.
+	// Could be related to https://github.com/golang/go/issues/23249.
+
+	// Output:
+	// === RUN   ParentTest/v_match_schema
+	//     value.go:131: on JSON decoded map: not matching schema: 2 issue(s)
+	//         issue #0:
+	//             key "items": on JSON decoded slice: on index 0: on JSON decoded map: not matching schema: 1 issue(s)
+	//             issue #0:
+	//                 key "parts": on JSON decoded slice: on index 0: on JSON decoded map: not matching schema: 1 issue(s)
+	//                 issue #0:
+	//                     key "price": on JSON decoded number: not greater than or equal to 0.000000
+	//                     got: json.Number
+	//                         "-1"
+	//         issue #1:
+	//             key "status": not one of ["open","closed"]
+	//             got: string
+	//                 "pending"
+	// --- FAIL: ParentTest/v_match_schema (0.00s)
+}
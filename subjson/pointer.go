@@ -0,0 +1,213 @@
+package subjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clarify/subtest"
+)
+
+// resolvePointer returns the value at the JSON pointer p within v, along with
+// the path of the value.
+func resolvePointer(v interface{}, p string) (json.RawMessage, subtest.Path, error) {
+	tokens, err := parsePointer(p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var raw json.RawMessage
+	if err := unmarshalJSON(v, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	var path subtest.Path
+	for _, tok := range tokens {
+		switch firstByte(raw) {
+		case '{':
+			var m map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &m); err != nil {
+				return nil, nil, subtest.FailGot(err.Error(), raw)
+			}
+			next, ok := m[tok]
+			if !ok {
+				return nil, nil, subtest.Failf("JSON pointer %q: key %q not found at %s", p, tok, path)
+			}
+			raw = next
+			path = append(path, subtest.PathElement{Kind: subtest.PathKey, Key: tok})
+		case '[':
+			var s []json.RawMessage
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return nil, nil, subtest.FailGot(err.Error(), raw)
+			}
+			i, ok := parseArrayIndex(tok)
+			if !ok || i >= len(s) {
+				return nil, nil, subtest.Failf("JSON pointer %q: index %q out of range at %s", p, tok, path)
+			}
+			raw = s[i]
+			path = append(path, subtest.PathElement{Kind: subtest.PathIndex, Index: i})
+		default:
+			return nil, nil, subtest.Failf("JSON pointer %q: not an object or array at %s", p, path)
+		}
+	}
+	return raw, path, nil
+}
+
+// parsePointer parses an RFC 6901 JSON pointer into a list of unescaped
+// reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, subtest.Failf("JSON pointer %q: must be empty or start with '/'", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	r := strings.NewReplacer("~1", "/", "~0", "~")
+	for i, tok := range tokens {
+		tokens[i] = r.Replace(tok)
+	}
+	return tokens, nil
+}
+
+// parseArrayIndex parses an array index token without leading zeros.
+func parseArrayIndex(tok string) (int, bool) {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, false
+	}
+	for _, r := range tok {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	i, err := strconv.Atoi(tok)
+	return i, err == nil
+}
+
+func firstByte(raw json.RawMessage) byte {
+	b := bytes.TrimLeft(raw, " \t\r\n")
+	if len(b) == 0 {
+		return 0
+	}
+	return b[0]
+}
+
+type jsonPathSegment struct {
+	wildcard bool
+	isIndex  bool
+	key      string
+	index    int
+}
+
+type jsonPathMatch struct {
+	path subtest.Path
+	raw  json.RawMessage
+}
+
+// parseJSONPath parses a JSONPath expression. The supported syntax is the root
+// selector `$`, child selectors `.name`, `['name']` and `["name"]`, index
+// selectors `[0]` and wildcard selectors `.*` and `[*]`.
+func parseJSONPath(p string) ([]jsonPathSegment, error) {
+	fail := func(msg string) error {
+		return subtest.Failf("JSONPath %q: %s", p, msg)
+	}
+	if !strings.HasPrefix(p, "$") {
+		return nil, fail("must start with '$'")
+	}
+
+	var segs []jsonPathSegment
+	s := p[1:]
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			name := s[:end]
+			s = s[end:]
+			switch name {
+			case "":
+				return nil, fail("unsupported syntax; expected name after '.'")
+			case "*":
+				segs = append(segs, jsonPathSegment{wildcard: true})
+			default:
+				segs = append(segs, jsonPathSegment{key: name})
+			}
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fail("missing ']'")
+			}
+			sel := s[1:end]
+			s = s[end+1:]
+			switch {
+			case sel == "*":
+				segs = append(segs, jsonPathSegment{wildcard: true})
+			case len(sel) >= 2 && (sel[0] == '\'' || sel[0] == '"') && sel[len(sel)-1] == sel[0]:
+				segs = append(segs, jsonPathSegment{key: sel[1 : len(sel)-1]})
+			default:
+				i, ok := parseArrayIndex(sel)
+				if !ok {
+					return nil, fail(fmt.Sprintf("unsupported selector [%s]", sel))
+				}
+				segs = append(segs, jsonPathSegment{isIndex: true, index: i})
+			}
+		default:
+			return nil, fail(fmt.Sprintf("unexpected character %q", s[0]))
+		}
+	}
+	return segs, nil
+}
+
+// matchJSONPath appends all values within raw matching segs to matches.
+func matchJSONPath(matches []jsonPathMatch, path subtest.Path, raw json.RawMessage, segs []jsonPathSegment) []jsonPathMatch {
+	if len(segs) == 0 {
+		return append(matches, jsonPathMatch{path: path, raw: raw})
+	}
+	seg, segs := segs[0], segs[1:]
+
+	switch firstByte(raw) {
+	case '{':
+		if seg.isIndex {
+			return matches
+		}
+		var m map[string]json.RawMessage
+		if json.Unmarshal(raw, &m) != nil {
+			return matches
+		}
+		keys := make([]string, 0, len(m))
+		if seg.wildcard {
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+		} else if _, ok := m[seg.key]; ok {
+			keys = append(keys, seg.key)
+		}
+		for _, k := range keys {
+			kPath := appendPath(path, subtest.PathElement{Kind: subtest.PathKey, Key: k})
+			matches = matchJSONPath(matches, kPath, m[k], segs)
+		}
+	case '[':
+		if !seg.isIndex && !seg.wildcard {
+			return matches
+		}
+		var s []json.RawMessage
+		if json.Unmarshal(raw, &s) != nil {
+			return matches
+		}
+		for i := range s {
+			if seg.isIndex && i != seg.index {
+				continue
+			}
+			iPath := appendPath(path, subtest.PathElement{Kind: subtest.PathIndex, Index: i})
+			matches = matchJSONPath(matches, iPath, s[i], segs)
+		}
+	}
+	return matches
+}
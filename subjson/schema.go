@@ -0,0 +1,565 @@
+package subjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/clarify/subtest"
+)
+
+const (
+	msgSchemaFalse = "rejected by false schema"
+	msgNotString   = "type is not string"
+	msgJSONType    = "JSON type is not"
+	msgEnum        = "not one of"
+)
+
+// CompileSchema compiles a JSON Schema (draft 2020-12) document into a check
+// that validates JSON test values of type string, []byte or json.RawMessage.
+// The doc value must be of the same types.
+//
+// Supported keywords are type, enum, const, properties, required,
+// additionalProperties, items, prefixItems, minItems, maxItems, pattern,
+// minLength, maxLength, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// allOf, anyOf, oneOf, not and $ref. References must point within the document,
+// e.g. "#/$defs/item". The keywords $schema, $id, $defs, definitions, $comment,
+// title, description, default, examples, deprecated, readOnly and writeOnly
+// are accepted, but do not affect validation. Any other keyword, e.g. format or
+// uniqueItems, causes a compile error.
+func CompileSchema(doc interface{}) (subtest.Check, error) {
+	root, err := decodeJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode JSON schema: %w", err)
+	}
+	sc := schemaCompiler{
+		root: root,
+		refs: make(map[string]*schemaRef),
+	}
+	c, err := sc.compile(root, "#")
+	if err != nil {
+		return nil, err
+	}
+	if err := sc.compileRefs(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// MustCompileSchema is like CompileSchema, but panics on error.
+func MustCompileSchema(doc interface{}) subtest.Check {
+	c, err := CompileSchema(doc)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// LoadSchemaFile reads and compiles the JSON Schema document stored in the
+// named file. See CompileSchema for details.
+func LoadSchemaFile(name string) (subtest.Check, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	c, err := CompileSchema(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
+
+type schemaCompiler struct {
+	root interface{}
+	refs map[string]*schemaRef
+}
+
+// schemaAllOf is a Check type that fails if any of it's members fails, like
+// subtest.AllOf. To reduce nesting in the output, a single failure is returned
+// as is.
+type schemaAllOf []subtest.Check
+
+func (cs schemaAllOf) Check(vf subtest.ValueFunc) error {
+	err := subtest.AllOf(cs).Check(vf)
+	if errs, ok := err.(subtest.Errors); ok && len(errs) == 1 {
+		return errs[0]
+	}
+	return err
+}
+
+// schemaRef is a check for a $ref keyword. References are compiled after the
+// rest of the document to allow recursive schemas.
+type schemaRef struct {
+	ref string
+	c   subtest.Check
+}
+
+func (r *schemaRef) Check(vf subtest.ValueFunc) error {
+	return r.c.Check(vf)
+}
+
+func (sc *schemaCompiler) compileRefs() error {
+	for {
+		var pending []*schemaRef
+		for _, r := range sc.refs {
+			if r.c == nil {
+				pending = append(pending, r)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		sort.Slice(pending, func(i, j int) bool { return pending[i].ref < pending[j].ref })
+		for _, r := range pending {
+			s, err := sc.resolve(r.ref)
+			if err != nil {
+				return err
+			}
+			if r.c, err = sc.compile(s, r.ref); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (sc *schemaCompiler) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("$ref %q: only references within the document are supported", ref)
+	}
+	tokens, err := parsePointer(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	s := sc.root
+	for _, tok := range tokens {
+		switch st := s.(type) {
+		case map[string]interface{}:
+			next, ok := st[tok]
+			if !ok {
+				return nil, fmt.Errorf("$ref %q: not found", ref)
+			}
+			s = next
+		case []interface{}:
+			i, ok := parseArrayIndex(tok)
+			if !ok || i >= len(st) {
+				return nil, fmt.Errorf("$ref %q: not found", ref)
+			}
+			s = st[i]
+		default:
+			return nil, fmt.Errorf("$ref %q: not found", ref)
+		}
+	}
+	return s, nil
+}
+
+func (sc *schemaCompiler) compile(s interface{}, ptr string) (subtest.Check, error) {
+	switch st := s.(type) {
+	case bool:
+		if st {
+			return subtest.Any(), nil
+		}
+		return subtest.CheckFunc(func(got interface{}) error {
+			return subtest.FailGot(msgSchemaFalse, got)
+		}), nil
+	case map[string]interface{}:
+		return sc.compileObject(st, ptr)
+	default:
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", ptr)
+	}
+}
+
+func (sc *schemaCompiler) compileObject(s map[string]interface{}, ptr string) (subtest.Check, error) {
+	var all schemaAllOf
+
+	keywords := make([]string, 0, len(s))
+	for k := range s {
+		keywords = append(keywords, k)
+	}
+	sort.Strings(keywords)
+
+	for _, k := range keywords {
+		c, err := sc.compileKeyword(s, k, ptr)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			all = append(all, c)
+		}
+	}
+	if hasAnyKey(s, "properties", "required", "additionalProperties") {
+		c, err := sc.compileProperties(s, ptr)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, onJSONType("object", OnMap(c)))
+	}
+	if hasAnyKey(s, "items", "prefixItems") {
+		c, err := sc.compileItems(s, ptr)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, onJSONType("array", OnSlice(c)))
+	}
+
+	switch len(all) {
+	case 0:
+		return subtest.Any(), nil
+	case 1:
+		return all[0], nil
+	default:
+		return all, nil
+	}
+}
+
+func (sc *schemaCompiler) compileKeyword(s map[string]interface{}, k, ptr string) (subtest.Check, error) {
+	v := s[k]
+	kPtr := ptr + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+	fail := func(msg string) error {
+		return fmt.Errorf("%s: %s", kPtr, msg)
+	}
+
+	switch k {
+	case "type":
+		types, ok := asStrings(v)
+		if !ok {
+			return nil, fail("must be a string or an array of strings")
+		}
+		return jsonTypeCheck(types), nil
+	case "enum":
+		values, ok := v.([]interface{})
+		if !ok {
+			return nil, fail("must be an array")
+		}
+		return enumCheck(values), nil
+	case "const":
+		return enumCheck([]interface{}{v}), nil
+	case "minItems", "maxItems":
+		n, ok := asFloat64(v)
+		if !ok {
+			return nil, fail("must be a number")
+		}
+		return onJSONType("array", OnSlice(subtest.OnLen(boundCheck(k, n)))), nil
+	case "minLength", "maxLength":
+		n, ok := asFloat64(v)
+		if !ok {
+			return nil, fail("must be a number")
+		}
+		return onJSONType("string", OnString(runeCount(boundCheck(k, n)))), nil
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+		n, ok := asFloat64(v)
+		if !ok {
+			return nil, fail("must be a number")
+		}
+		return onJSONType("number", OnNumber(boundCheck(k, n))), nil
+	case "pattern":
+		p, ok := v.(string)
+		if !ok {
+			return nil, fail("must be a string")
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fail(err.Error())
+		}
+		return onJSONType("string", OnString(subtest.MatchRegexp(re))), nil
+	case "allOf", "anyOf", "oneOf":
+		cs, err := sc.compileList(v, kPtr)
+		if err != nil {
+			return nil, err
+		}
+		switch k {
+		case "allOf":
+			return subtest.AllOf(cs), nil
+		case "anyOf":
+			return subtest.AnyOf(cs), nil
+		default:
+			return subtest.OneOf(cs), nil
+		}
+	case "not":
+		c, err := sc.compile(v, kPtr)
+		if err != nil {
+			return nil, err
+		}
+		return subtest.Not(c), nil
+	case "$ref":
+		ref, ok := v.(string)
+		if !ok {
+			return nil, fail("must be a string")
+		}
+		if _, err := sc.resolve(ref); err != nil {
+			return nil, fmt.Errorf("%s: %w", kPtr, err)
+		}
+		r, ok := sc.refs[ref]
+		if !ok {
+			r = &schemaRef{ref: ref}
+			sc.refs[ref] = r
+		}
+		return r, nil
+	case "properties", "required", "additionalProperties", "items", "prefixItems":
+		// Compiled by compileProperties and compileItems.
+		return nil, nil
+	case "$schema", "$id", "$defs", "definitions", "$comment", "title", "description",
+		"default", "examples", "deprecated", "readOnly", "writeOnly":
+		return nil, nil
+	}
+	return nil, fail("unsupported keyword")
+}
+
+func (sc *schemaCompiler) compileProperties(s map[string]interface{}, ptr string) (subtest.Check, error) {
+	schema := subtest.Schema{
+		Fields:           subtest.Fields{},
+		Required:         []interface{}{},
+		AdditionalFields: subtest.Any(),
+	}
+
+	if v, ok := s["properties"]; ok {
+		props, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s/properties: must be an object", ptr)
+		}
+		for name, ps := range props {
+			c, err := sc.compile(ps, ptr+"/properties/"+name)
+			if err != nil {
+				return nil, err
+			}
+			schema.Fields[name] = c
+		}
+	}
+	if v, ok := s["required"]; ok {
+		names, ok := asStrings(v)
+		if !ok {
+			return nil, fmt.Errorf("%s/required: must be an array of strings", ptr)
+		}
+		for _, name := range names {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	if v, ok := s["additionalProperties"]; ok {
+		if b, ok := v.(bool); ok && !b {
+			schema.AdditionalFields = nil
+		} else {
+			c, err := sc.compile(v, ptr+"/additionalProperties")
+			if err != nil {
+				return nil, err
+			}
+			schema.AdditionalFields = c
+		}
+	}
+	return schema, nil
+}
+
+func (sc *schemaCompiler) compileItems(s map[string]interface{}, ptr string) (subtest.Check, error) {
+	var prefix []subtest.Check
+	if v, ok := s["prefixItems"]; ok {
+		var err error
+		if prefix, err = sc.compileList(v, ptr+"/prefixItems"); err != nil {
+			return nil, err
+		}
+	}
+	var items subtest.Check
+	if v, ok := s["items"]; ok {
+		var err error
+		if items, err = sc.compile(v, ptr+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	return subtest.CheckFunc(func(got interface{}) error {
+		l, _ := got.([]json.RawMessage)
+
+		var errs subtest.Errors
+		for i := range l {
+			c := items
+			if i < len(prefix) {
+				c = prefix[i]
+			}
+			if c == nil {
+				continue
+			}
+			if err := subtest.OnIndex(i, c)(got); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		switch len(errs) {
+		case 0:
+			return nil
+		case 1:
+			return errs[0]
+		default:
+			return errs
+		}
+	}), nil
+}
+
+func (sc *schemaCompiler) compileList(v interface{}, ptr string) ([]subtest.Check, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an array", ptr)
+	}
+	cs := make([]subtest.Check, 0, len(l))
+	for i, s := range l {
+		c, err := sc.compile(s, fmt.Sprintf("%s/%d", ptr, i))
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+func hasAnyKey(m map[string]interface{}, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := m[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func asStrings(v interface{}) ([]string, bool) {
+	switch vt := v.(type) {
+	case string:
+		return []string{vt}, true
+	case []interface{}:
+		l := make([]string, 0, len(vt))
+		for _, e := range vt {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
+			}
+			l = append(l, s)
+		}
+		return l, true
+	}
+	return nil, false
+}
+
+func asFloat64(v interface{}) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func boundCheck(keyword string, n float64) subtest.CheckFunc {
+	switch keyword {
+	case "minimum", "minItems", "minLength":
+		return subtest.GreaterThanOrEqual(n)
+	case "exclusiveMinimum":
+		return subtest.GreaterThan(n)
+	case "exclusiveMaximum":
+		return subtest.LessThan(n)
+	default:
+		return subtest.LessThanOrEqual(n)
+	}
+}
+
+// runeCount returns a check function where the number of runes in a test value
+// of type string is passed on to c.
+func runeCount(c subtest.Check) subtest.CheckFunc {
+	return func(got interface{}) error {
+		s, ok := got.(string)
+		if !ok {
+			return subtest.FailGot(msgNotString, got)
+		}
+		if err := c.Check(subtest.Value(utf8.RuneCountInString(s))); err != nil {
+			return decoderError("rune count", err)
+		}
+		return nil
+	}
+}
+
+// jsonType returns the JSON Schema type name of a raw JSON value.
+func jsonType(v interface{}) (string, bool) {
+	var raw json.RawMessage
+	if err := unmarshalJSON(v, &raw); err != nil {
+		return "", false
+	}
+	switch firstByte(raw) {
+	case '{':
+		return "object", true
+	case '[':
+		return "array", true
+	case '"':
+		return "string", true
+	case 't', 'f':
+		return "boolean", true
+	case 'n':
+		return "null", true
+	default:
+		return "number", true
+	}
+}
+
+func isJSONInteger(v interface{}) bool {
+	var n json.Number
+	if err := unmarshalJSON(v, &n); err != nil {
+		return false
+	}
+	var r big.Rat
+	if _, ok := r.SetString(string(n)); !ok {
+		return false
+	}
+	return r.IsInt()
+}
+
+func matchJSONType(got interface{}, t string) bool {
+	gt, ok := jsonType(got)
+	switch {
+	case !ok:
+		return false
+	case t == "integer":
+		return gt == "number" && isJSONInteger(got)
+	default:
+		return gt == t
+	}
+}
+
+// jsonTypeCheck returns a check function that fails if the test value is not
+// one of the listed JSON Schema types.
+func jsonTypeCheck(types []string) subtest.CheckFunc {
+	return func(got interface{}) error {
+		for _, t := range types {
+			if matchJSONType(got, t) {
+				return nil
+			}
+		}
+		return subtest.FailGot(fmt.Sprintf("%s %s", msgJSONType, strings.Join(types, " or ")), got)
+	}
+}
+
+// onJSONType returns a check function where c is only applied to test values
+// of JSON Schema type t.
+func onJSONType(t string, c subtest.Check) subtest.CheckFunc {
+	return func(got interface{}) error {
+		if !matchJSONType(got, t) {
+			return nil
+		}
+		return c.Check(subtest.Value(got))
+	}
+}
+
+// enumCheck returns a check function that fails if the test value is not
+// semantically equal to any of values.
+func enumCheck(values []interface{}) subtest.CheckFunc {
+	return func(got interface{}) error {
+		gv, err := decodeJSON(got)
+		if err != nil {
+			return err
+		}
+		for _, ev := range values {
+			var c jsonComparer
+			c.compare(nil, gv, ev, modeEqual)
+			if len(c.lines) == 0 {
+				return nil
+			}
+		}
+		return subtest.FailGot(fmt.Sprintf("%s %s", msgEnum, formatJSON(values)), gv)
+	}
+}
@@ -0,0 +1,207 @@
+package subjson_test
+
+import (
+	"testing"
+
+	"github.com/clarify/subtest"
+	"github.com/clarify/subtest/subjson"
+)
+
+func TestCompileSchema_keywords(t *testing.T) {
+	type value struct {
+		v     string
+		valid bool
+	}
+	for _, tc := range []struct {
+		name   string
+		schema string
+		values []value
+	}{
+		{
+			name:   "boolean schema true",
+			schema: `true`,
+			values: []value{{`1`, true}, {`null`, true}},
+		},
+		{
+			name:   "boolean schema false",
+			schema: `false`,
+			values: []value{{`1`, false}, {`{}`, false}},
+		},
+		{
+			name:   "type integer",
+			schema: `{"type": "integer"}`,
+			values: []value{{`1`, true}, {`1.0`, true}, {`-2e2`, true}, {`1.5`, false}, {`"1"`, false}},
+		},
+		{
+			name:   "type list",
+			schema: `{"type": ["string", "null"]}`,
+			values: []value{{`"a"`, true}, {`null`, true}, {`1`, false}, {`false`, false}},
+		},
+		{
+			name:   "enum",
+			schema: `{"enum": ["a", 1, null]}`,
+			values: []value{{`"a"`, true}, {`1.0`, true}, {`null`, true}, {`"b"`, false}},
+		},
+		{
+			name:   "const",
+			schema: `{"const": {"a": [1, 2]}}`,
+			values: []value{{`{"a": [1, 2.0]}`, true}, {`{"a": [1]}`, false}, {`{"a": [1, 2], "b": 1}`, false}},
+		},
+		{
+			name:   "minimum and maximum",
+			schema: `{"minimum": 1, "maximum": 3}`,
+			values: []value{{`1`, true}, {`3`, true}, {`0.9`, false}, {`3.1`, false}, {`"0"`, true}},
+		},
+		{
+			name:   "exclusiveMinimum and exclusiveMaximum",
+			schema: `{"exclusiveMinimum": 1, "exclusiveMaximum": 3}`,
+			values: []value{{`1.1`, true}, {`2.9`, true}, {`1`, false}, {`3`, false}},
+		},
+		{
+			name:   "minLength",
+			schema: `{"minLength": 2}`,
+			values: []value{{`"ab"`, true}, {`"é"`, false}, {`"éé"`, true}, {`1`, true}},
+		},
+		{
+			name:   "maxLength",
+			schema: `{"maxLength": 1}`,
+			values: []value{{`"é"`, true}, {`"ab"`, false}, {`[1, 2]`, true}},
+		},
+		{
+			name:   "pattern",
+			schema: `{"pattern": "^a+$"}`,
+			values: []value{{`"aa"`, true}, {`"ab"`, false}, {`1`, true}},
+		},
+		{
+			name:   "minItems and maxItems",
+			schema: `{"minItems": 1, "maxItems": 2}`,
+			values: []value{{`[1]`, true}, {`[1, 2]`, true}, {`[]`, false}, {`[1, 2, 3]`, false}, {`"abc"`, true}},
+		},
+		{
+			name:   "items",
+			schema: `{"items": {"type": "integer"}}`,
+			values: []value{{`[]`, true}, {`[1, 2]`, true}, {`[1, "a"]`, false}},
+		},
+		{
+			name:   "prefixItems",
+			schema: `{"prefixItems": [{"type": "string"}, {"type": "integer"}], "items": false}`,
+			values: []value{{`["a", 1]`, true}, {`["a"]`, true}, {`[1]`, false}, {`["a", 1, 2]`, false}},
+		},
+		{
+			name:   "required",
+			schema: `{"required": ["a"]}`,
+			values: []value{{`{"a": null}`, true}, {`{"b": 1}`, false}, {`[]`, true}},
+		},
+		{
+			name:   "properties",
+			schema: `{"properties": {"a": {"type": "string"}, "b": false}}`,
+			values: []value{{`{"a": "x"}`, true}, {`{"c": 1}`, true}, {`{"a": 1}`, false}, {`{"b": 1}`, false}},
+		},
+		{
+			name:   "additionalProperties false",
+			schema: `{"properties": {"a": true}, "additionalProperties": false}`,
+			values: []value{{`{"a": 1}`, true}, {`{"a": 1, "b": 1}`, false}},
+		},
+		{
+			name:   "additionalProperties schema",
+			schema: `{"properties": {"a": true}, "additionalProperties": {"type": "integer"}}`,
+			values: []value{{`{"a": "x", "b": 1}`, true}, {`{"b": "x"}`, false}},
+		},
+		{
+			name:   "allOf",
+			schema: `{"allOf": [{"minimum": 1}, {"maximum": 3}]}`,
+			values: []value{{`2`, true}, {`0`, false}, {`4`, false}},
+		},
+		{
+			name:   "anyOf",
+			schema: `{"anyOf": [{"type": "string"}, {"type": "null"}]}`,
+			values: []value{{`"a"`, true}, {`null`, true}, {`1`, false}},
+		},
+		{
+			name:   "oneOf",
+			schema: `{"oneOf": [{"type": "integer"}, {"minimum": 2}]}`,
+			values: []value{{`1`, true}, {`2.5`, true}, {`3`, false}, {`1.5`, false}},
+		},
+		{
+			name:   "not",
+			schema: `{"not": {"type": "string"}}`,
+			values: []value{{`1`, true}, {`"a"`, false}},
+		},
+		{
+			name:   "$ref",
+			schema: `{"$defs": {"n": {"type": "integer"}}, "$ref": "#/$defs/n"}`,
+			values: []value{{`1`, true}, {`"a"`, false}},
+		},
+		{
+			name:   "recursive $ref",
+			schema: `{"$defs": {"l": {"type": "array", "items": {"$ref": "#/$defs/l"}}}, "$ref": "#/$defs/l"}`,
+			values: []value{{`[[], [[]]]`, true}, {`[[1]]`, false}},
+		},
+		{
+			name:   "annotations",
+			schema: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "$id": "x", "$comment": "c", "title": "t", "description": "d", "default": 1, "examples": [1], "deprecated": true, "readOnly": true, "writeOnly": false}`,
+			values: []value{{`1`, true}, {`"a"`, true}},
+		},
+	} {
+		tc := tc
+		t.Run("given a schema with "+tc.name, func(t *testing.T) {
+			c, err := subjson.CompileSchema(tc.schema)
+			if !t.Run("when compiling the schema", subtest.Value(err).NoError()) {
+				t.FailNow()
+			}
+			for _, v := range tc.values {
+				vf := subtest.Value(c.Check(subtest.Value(v.v)))
+				if v.valid {
+					t.Run("then "+v.v+" should be valid", vf.NoError())
+				} else {
+					t.Run("then "+v.v+" should be invalid", vf.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestCompileSchema_errors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{"invalid JSON", `{`, `^decode JSON schema: `},
+		{"a non-schema document", `1`, `^#: schema must be an object or boolean$`},
+		{"a malformed type", `{"type": 1}`, `^#/type: must be a string or an array of strings$`},
+		{"a malformed enum", `{"enum": 1}`, `^#/enum: must be an array$`},
+		{"a malformed minItems", `{"minItems": "1"}`, `^#/minItems: must be a number$`},
+		{"a malformed maxLength", `{"maxLength": true}`, `^#/maxLength: must be a number$`},
+		{"a malformed minimum", `{"minimum": null}`, `^#/minimum: must be a number$`},
+		{"a malformed exclusiveMaximum", `{"exclusiveMaximum": []}`, `^#/exclusiveMaximum: must be a number$`},
+		{"a malformed pattern type", `{"pattern": 1}`, `^#/pattern: must be a string$`},
+		{"an invalid pattern", `{"pattern": "("}`, `^#/pattern: error parsing regexp: `},
+		{"a malformed allOf", `{"allOf": {}}`, `^#/allOf: must be an array$`},
+		{"a malformed anyOf member", `{"anyOf": [true, 1]}`, `^#/anyOf/1: schema must be an object or boolean$`},
+		{"a malformed oneOf member", `{"oneOf": [{"type": 1}]}`, `^#/oneOf/0/type: must be a string or an array of strings$`},
+		{"a malformed not", `{"not": 1}`, `^#/not: schema must be an object or boolean$`},
+		{"a malformed $ref", `{"$ref": 1}`, `^#/\$ref: must be a string$`},
+		{"a missing $ref target", `{"$ref": "#/$defs/missing"}`, `^#/\$ref: \$ref "#/\$defs/missing": not found$`},
+		{"an external $ref", `{"$ref": "other.json"}`, `^#/\$ref: \$ref "other.json": only references within the document are supported$`},
+		{"a malformed $ref target", `{"$defs": {"a": 1}, "$ref": "#/$defs/a"}`, `^#/\$defs/a: schema must be an object or boolean$`},
+		{"malformed properties", `{"properties": 1}`, `^#/properties: must be an object$`},
+		{"a malformed property", `{"properties": {"a": 1}}`, `^#/properties/a: schema must be an object or boolean$`},
+		{"malformed required", `{"required": [1]}`, `^#/required: must be an array of strings$`},
+		{"malformed additionalProperties", `{"additionalProperties": 1}`, `^#/additionalProperties: schema must be an object or boolean$`},
+		{"malformed prefixItems", `{"prefixItems": {}}`, `^#/prefixItems: must be an array$`},
+		{"malformed items", `{"items": 1}`, `^#/items: schema must be an object or boolean$`},
+		{"an unsupported uniqueItems", `{"uniqueItems": true}`, `^#/uniqueItems: unsupported keyword$`},
+		{"an unsupported contains", `{"contains": {"type": "string"}}`, `^#/contains: unsupported keyword$`},
+		{"an unsupported nested format", `{"properties": {"a": {"format": "date"}}}`, `^#/properties/a/format: unsupported keyword$`},
+		{"an unknown keyword", `{"tpye": "string"}`, `^#/tpye: unsupported keyword$`},
+	} {
+		tc := tc
+		t.Run("given a schema with "+tc.name, func(t *testing.T) {
+			_, err := subjson.CompileSchema(tc.schema)
+			t.Run("then compiling it should fail",
+				subtest.Value(err).MatchPattern(tc.err),
+			)
+		})
+	}
+}
@@ -79,6 +79,16 @@ func Interface(v interface{}) subtest.ValueFunc {
 	}
 }
 
+// Pointer returns a ValueFunc that extracts the JSON value at the RFC 6901 JSON
+// Pointer p from v as a json.RawMessage. Only the values along the pointer are
+// decoded.
+func Pointer(v interface{}, p string) subtest.ValueFunc {
+	return func() (interface{}, error) {
+		raw, _, err := resolvePointer(v, p)
+		return raw, err
+	}
+}
+
 // Len returns a ValueFunc that returns the length of the decoded value.
 func Len(v interface{}) subtest.ValueFunc {
 	return func() (interface{}, error) {
@@ -0,0 +1,79 @@
+package subtest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TableRow describes a single row within a Table.
+type TableRow struct {
+	// Name is used as the name of the sub-test for the row.
+	Name string
+	// Value returns the test value for the row.
+	Value ValueFunc
+	// Check is run against the test value for the row.
+	Check Check
+	// Skip, if set, skips the row.
+	Skip bool
+	// Only, if set on one or more rows within a table, skips all rows where
+	// Only is not set.
+	Only bool
+	// Parallel, if set, signals that the row is to be run in parallel with
+	// other parallel rows.
+	Parallel bool
+}
+
+// Table allows declaring table-driven tests, where each row is run as a
+// separate sub-test.
+type Table []TableRow
+
+// Run runs one sub-test per row in tbl. When one or more rows fail, a summary
+// of the failing rows is logged to t once all rows have completed.
+func (tbl Table) Run(t *testing.T) {
+	t.Helper()
+
+	var only bool
+	for _, row := range tbl {
+		if row.Only {
+			only = true
+			break
+		}
+	}
+
+	var mu sync.Mutex
+	var failed []string
+	t.Cleanup(func() {
+		t.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		if len(failed) > 0 {
+			t.Logf("%d of %d table row(s) failed:\n%s", len(failed), len(tbl), strings.Join(failed, "\n"))
+		}
+	})
+
+	for _, row := range tbl {
+		row := row
+		t.Run(row.Name, func(t *testing.T) {
+			t.Helper()
+
+			switch {
+			case row.Skip:
+				t.Skip("row marked as skip")
+			case only && !row.Only:
+				t.Skip("row not marked as only")
+			}
+			if row.Parallel {
+				t.Parallel()
+			}
+			defer func() {
+				if t.Failed() {
+					mu.Lock()
+					failed = append(failed, t.Name())
+					mu.Unlock()
+				}
+			}()
+			row.Value.Test(row.Check)(t)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package subtest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+func TestTable(t *testing.T) {
+	failing := subtest.ValueFunc(func() (interface{}, error) {
+		return nil, errors.New("should not be called")
+	})
+
+	t.Run("given a table with passing rows", subtest.Table{
+		{Name: "when checking 42", Value: subtest.Value(42), Check: subtest.DeepEqual(42)},
+		{Name: "when checking foo", Value: subtest.Value("foo"), Check: subtest.DeepEqual("foo")},
+	}.Run)
+
+	t.Run("given a table with parallel rows", subtest.Table{
+		{Name: "when checking 1", Value: subtest.Value(1), Check: subtest.LessThan(2), Parallel: true},
+		{Name: "when checking 2", Value: subtest.Value(2), Check: subtest.LessThan(3), Parallel: true},
+	}.Run)
+
+	t.Run("given a table with a skipped failing row", subtest.Table{
+		{Name: "when checking 42", Value: subtest.Value(42), Check: subtest.DeepEqual(42)},
+		{Name: "when checking a failing value", Value: failing, Check: subtest.Any(), Skip: true},
+	}.Run)
+
+	t.Run("given a table with a row marked as only", func(t *testing.T) {
+		var ran []string
+		record := func(name string) subtest.CheckFunc {
+			return func(got interface{}) error {
+				ran = append(ran, name)
+				return nil
+			}
+		}
+		t.Run("when running the table", subtest.Table{
+			{Name: "a", Value: subtest.Value(nil), Check: record("a")},
+			{Name: "b", Value: subtest.Value(nil), Check: record("b"), Only: true},
+			{Name: "c", Value: failing, Check: record("c")},
+		}.Run)
+		t.Run("then only the marked row should be run",
+			subtest.Value(ran).DeepEqual([]string{"b"}),
+		)
+	})
+}
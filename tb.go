@@ -0,0 +1,73 @@
+package subtest
+
+import "testing"
+
+// TB is the minimal interface required for running checks. Unlike
+// testing.TB, it can be implemented by custom test harnesses, e.g. to run
+// checks as part of integration tests in production binaries. Use WrapT or
+// WrapB to get a TB from *testing.T or *testing.B.
+type TB interface {
+	Helper()
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+	Run(name string, f func(t TB)) bool
+	Cleanup(f func())
+}
+
+// TestTB returns a test function that fails fatally with the error returned
+// by f.
+func TestTB(f func() error) func(t TB) {
+	return func(t TB) {
+		t.Helper()
+
+		if err := f(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestTB returns a test function that fails fatally with the error returned by
+// c.Check(vf).
+func (vf ValueFunc) TestTB(c Check) func(t TB) {
+	return func(t TB) {
+		t.Helper()
+
+		if err := c.Check(vf); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// WrapT returns a TB that runs sub-tests via t.Run.
+func WrapT(t *testing.T) TB {
+	return tbT{t}
+}
+
+type tbT struct {
+	*testing.T
+}
+
+func (t tbT) Run(name string, f func(t TB)) bool {
+	t.T.Helper()
+	return t.T.Run(name, func(t *testing.T) {
+		t.Helper()
+		f(tbT{t})
+	})
+}
+
+// WrapB returns a TB that runs sub-benchmarks via b.Run.
+func WrapB(b *testing.B) TB {
+	return tbB{b}
+}
+
+type tbB struct {
+	*testing.B
+}
+
+func (b tbB) Run(name string, f func(t TB)) bool {
+	b.B.Helper()
+	return b.B.Run(name, func(b *testing.B) {
+		b.Helper()
+		f(tbB{b})
+	})
+}
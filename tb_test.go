@@ -0,0 +1,63 @@
+package subtest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/clarify/subtest"
+)
+
+// harness is a custom TB implementation recording failures.
+type harness struct {
+	name     string
+	failures *[]string
+}
+
+func (h harness) Helper() {}
+
+func (h harness) Error(args ...interface{}) {
+	*h.failures = append(*h.failures, h.name+": "+fmt.Sprint(args...))
+}
+
+func (h harness) Fatal(args ...interface{}) {
+	h.Error(args...)
+}
+
+func (h harness) Run(name string, f func(t subtest.TB)) bool {
+	n := len(*h.failures)
+	f(harness{name: h.name + "/" + name, failures: h.failures})
+	return len(*h.failures) == n
+}
+
+func (h harness) Cleanup(f func()) {}
+
+func TestTB(t *testing.T) {
+	t.Run("given a custom harness", func(t *testing.T) {
+		var failures []string
+		h := harness{name: "root", failures: &failures}
+
+		t.Run("when running a passing check", func(t *testing.T) {
+			ok := h.Run("pass", subtest.Value(42).TestTB(subtest.DeepEqual(42)))
+			t.Run("then it should report success", subtest.Value(ok).DeepEqual(true))
+		})
+		t.Run("when running a failing function", func(t *testing.T) {
+			ok := h.Run("fail", subtest.TestTB(func() error { return errors.New("fail") }))
+			t.Run("then it should report failure", subtest.Value(ok).DeepEqual(false))
+			t.Run("then the failure should be recorded",
+				subtest.Value(failures).DeepEqual([]string{"root/fail: fail"}),
+			)
+		})
+	})
+	t.Run("given a wrapped *testing.T", func(t *testing.T) {
+		tb := subtest.WrapT(t)
+		tb.Run("then a passing check should pass", subtest.Value(42).TestTB(subtest.DeepEqual(42)))
+	})
+}
+
+func BenchmarkTB(b *testing.B) {
+	tb := subtest.WrapB(b)
+	for i := 0; i < b.N; i++ {
+		subtest.Value(i).TestTB(subtest.GreaterThanOrEqual(0))(tb)
+	}
+}
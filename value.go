@@ -163,6 +163,21 @@ func (vf ValueFunc) NumericEqual(v float64) func(t *testing.T) {
 	return vf.Test(NumericEqual(v))
 }
 
+// NumericEqualAbs is equivalent to vf.Test(NumericEqualAbs(v, tolerance)).
+func (vf ValueFunc) NumericEqualAbs(v, tolerance float64) func(t *testing.T) {
+	return vf.Test(NumericEqualAbs(v, tolerance))
+}
+
+// NumericEqualRel is equivalent to vf.Test(NumericEqualRel(v, tolerance)).
+func (vf ValueFunc) NumericEqualRel(v, tolerance float64) func(t *testing.T) {
+	return vf.Test(NumericEqualRel(v, tolerance))
+}
+
+// NumericEqualULP is equivalent to vf.Test(NumericEqualULP(v, ulps)).
+func (vf ValueFunc) NumericEqualULP(v float64, ulps uint64) func(t *testing.T) {
+	return vf.Test(NumericEqualULP(v, ulps))
+}
+
 // NotBefore is equivalent to vf.Test(NotBefore(v)).
 func (vf ValueFunc) NotBefore(v time.Time) func(t *testing.T) {
 	return vf.Test(NotBefore(v))
@@ -244,6 +259,11 @@ func (vf ValueFunc) ErrorIs(target error) func(t *testing.T) {
 	return vf.Test(ErrorIs(target))
 }
 
+// ErrorAs is equivalent to vf.Test(ErrorAs(target, c)).
+func (vf ValueFunc) ErrorAs(target interface{}, c Check) func(t *testing.T) {
+	return vf.Test(ErrorAs(target, c))
+}
+
 // ContainsMatch is equivalent to vf.Test(ContainsMatch{c}).
 func (vf ValueFunc) ContainsMatch(c Check) func(t *testing.T) {
 	return vf.Test(ContainsMatch(c))
@@ -253,3 +273,63 @@ func (vf ValueFunc) ContainsMatch(c Check) func(t *testing.T) {
 func (vf ValueFunc) Contains(v interface{}) func(t *testing.T) {
 	return vf.Test(Contains(v))
 }
+
+// ElementsMatch is equivalent to vf.Test(ElementsMatch(expect)).
+func (vf ValueFunc) ElementsMatch(expect interface{}) func(t *testing.T) {
+	return vf.Test(ElementsMatch(expect))
+}
+
+// ElementsMatchChecks is equivalent to vf.Test(ElementsMatchChecks(cs...)).
+func (vf ValueFunc) ElementsMatchChecks(cs ...Check) func(t *testing.T) {
+	return vf.Test(ElementsMatchChecks(cs...))
+}
+
+// Each is equivalent to vf.Test(Each(c)).
+func (vf ValueFunc) Each(c Check) func(t *testing.T) {
+	return vf.Test(Each(c))
+}
+
+// None is equivalent to vf.Test(None(c)).
+func (vf ValueFunc) None(c Check) func(t *testing.T) {
+	return vf.Test(None(c))
+}
+
+// Sorted is equivalent to vf.Test(Sorted()).
+func (vf ValueFunc) Sorted() func(t *testing.T) {
+	return vf.Test(Sorted())
+}
+
+// SortedBy is equivalent to vf.Test(SortedBy(less)).
+func (vf ValueFunc) SortedBy(less func(a, b interface{}) bool) func(t *testing.T) {
+	return vf.Test(SortedBy(less))
+}
+
+// StrictlyIncreasing is equivalent to vf.Test(StrictlyIncreasing()).
+func (vf ValueFunc) StrictlyIncreasing() func(t *testing.T) {
+	return vf.Test(StrictlyIncreasing())
+}
+
+// Unique is equivalent to vf.Test(Unique()).
+func (vf ValueFunc) Unique() func(t *testing.T) {
+	return vf.Test(Unique())
+}
+
+// UniqueBy is equivalent to vf.Test(UniqueBy(key)).
+func (vf ValueFunc) UniqueBy(key func(v interface{}) interface{}) func(t *testing.T) {
+	return vf.Test(UniqueBy(key))
+}
+
+// Panic is equivalent to vf.Test(Panic()).
+func (vf ValueFunc) Panic() func(t *testing.T) {
+	return vf.Test(Panic())
+}
+
+// NoPanic is equivalent to vf.Test(NoPanic()).
+func (vf ValueFunc) NoPanic() func(t *testing.T) {
+	return vf.Test(NoPanic())
+}
+
+// PanicMatch is equivalent to vf.Test(PanicMatch(c)).
+func (vf ValueFunc) PanicMatch(c Check) func(t *testing.T) {
+	return vf.Test(PanicMatch(c))
+}
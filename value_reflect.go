@@ -0,0 +1,199 @@
+package subtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Key returns a new ValueFunc for the value stored under key in the map v.
+func Key(v interface{}, key interface{}) ValueFunc {
+	return func() (interface{}, error) {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return nil, FailGot(msgNotMapType, v)
+		}
+		kt := rv.Type().Key()
+		rk := reflect.ValueOf(key)
+		switch {
+		case !rk.IsValid() && isNilKind(kt.Kind()):
+			rk = reflect.Zero(kt)
+		case !rk.IsValid() || !rk.Type().AssignableTo(kt):
+			return nil, FailGot(msgKeyType, key)
+		case !rk.Type().Comparable():
+			return nil, FailGot(msgKeyComparable, key)
+		}
+		rv = rv.MapIndex(rk)
+		if !rv.IsValid() {
+			return nil, errors.New(msgMissingKey)
+		}
+		return rv.Interface(), nil
+	}
+}
+
+// Keys returns a new ValueFunc for a sorted slice of the keys in the map v.
+// The slice element type matches the map key type.
+func Keys(v interface{}) ValueFunc {
+	return func() (interface{}, error) {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return nil, FailGot(msgNotMapType, v)
+		}
+		keys := sortedKeys(rv)
+		l := reflect.MakeSlice(reflect.SliceOf(rv.Type().Key()), 0, len(keys))
+		return reflect.Append(l, keys...).Interface(), nil
+	}
+}
+
+// Values returns a new ValueFunc for a slice of the values in the map v,
+// sorted by key. The slice element type matches the map value type.
+func Values(v interface{}) ValueFunc {
+	return func() (interface{}, error) {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return nil, FailGot(msgNotMapType, v)
+		}
+		keys := sortedKeys(rv)
+		l := reflect.MakeSlice(reflect.SliceOf(rv.Type().Elem()), 0, len(keys))
+		for _, k := range keys {
+			l = reflect.Append(l, rv.MapIndex(k))
+		}
+		return l.Interface(), nil
+	}
+}
+
+// sortedKeys returns the keys of the map rv in natural order for numeric and
+// string kinds, and in order of their default format otherwise.
+func sortedKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		default:
+			return fmt.Sprint(a) < fmt.Sprint(b)
+		}
+	})
+	return keys
+}
+
+// Field returns a new ValueFunc for the field with the given name in v.
+// Accepted input types are structs and pointers to structs. Promoted fields
+// from embedded structs are also accepted.
+func Field(v interface{}, name string) ValueFunc {
+	return func() (interface{}, error) {
+		rv, err := structValue(reflect.ValueOf(v), v)
+		if err != nil {
+			return nil, err
+		}
+		sf, ok := rv.Type().FieldByName(name)
+		if !ok {
+			return nil, FailGot(fmt.Sprintf("%s %q", msgNoSuchField, name), v)
+		}
+		for i, x := range sf.Index {
+			if i > 0 {
+				if rv, err = structValue(rv, v); err != nil {
+					return nil, err
+				}
+			}
+			rv = rv.Field(x)
+		}
+		if !rv.CanInterface() {
+			return nil, FailGot(fmt.Sprintf("%s %q", msgUnexportedField, name), v)
+		}
+		return rv.Interface(), nil
+	}
+}
+
+// structValue dereferences rv until it's a struct value. Errors are reported
+// with v as the test value.
+func structValue(rv reflect.Value, v interface{}) (reflect.Value, error) {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, FailGot(msgNilPointer, v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, FailGot(msgNotStructType, v)
+	}
+	return rv, nil
+}
+
+// Method returns a new ValueFunc that calls the method with the given name on
+// v, passing on args, and returns the first result. When the method returns
+// more than one result and the last result is of type error, a non-nil error
+// is returned as the ValueFunc error. Methods with a pointer receiver are also
+// found when v is not a pointer, and are then called on a copy of v.
+func Method(v interface{}, name string, args ...interface{}) ValueFunc {
+	return func() (interface{}, error) {
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() {
+			return nil, FailGot(fmt.Sprintf("%s %q", msgNoSuchMethod, name), v)
+		}
+		m := rv.MethodByName(name)
+		if !m.IsValid() && rv.Kind() != reflect.Ptr {
+			p := reflect.New(rv.Type())
+			p.Elem().Set(rv)
+			m = p.MethodByName(name)
+		}
+		if !m.IsValid() {
+			return nil, FailGot(fmt.Sprintf("%s %q", msgNoSuchMethod, name), v)
+		}
+		mt := m.Type()
+		if mt.NumOut() == 0 {
+			return nil, FailGot(msgMethodNoResult, v)
+		}
+		in, ok := methodArgs(mt, args)
+		if !ok {
+			return nil, FailExpect(msgMethodArgs, args, mt)
+		}
+
+		out := m.Call(in)
+		if last := len(out) - 1; last > 0 && mt.Out(last) == errorType && !out[last].IsNil() {
+			return nil, out[last].Interface().(error)
+		}
+		return out[0].Interface(), nil
+	}
+}
+
+// methodArgs returns args as values matching the input of the function type
+// ft, or false if they don't match.
+func methodArgs(ft reflect.Type, args []interface{}) ([]reflect.Value, bool) {
+	n := ft.NumIn()
+	if ft.IsVariadic() {
+		if len(args) < n-1 {
+			return nil, false
+		}
+	} else if len(args) != n {
+		return nil, false
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var at reflect.Type
+		switch {
+		case ft.IsVariadic() && i >= n-1:
+			at = ft.In(n - 1).Elem()
+		default:
+			at = ft.In(i)
+		}
+		rv := reflect.ValueOf(arg)
+		switch {
+		case !rv.IsValid() && isNilKind(at.Kind()):
+			rv = reflect.Zero(at)
+		case !rv.IsValid() || !rv.Type().AssignableTo(at):
+			return nil, false
+		}
+		in[i] = rv
+	}
+	return in, true
+}